@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
 	"log/slog"
+	"time"
 
 	"github.com/adhikag24/policy-based-permission-model/domain/blogs"
 	"github.com/adhikag24/policy-based-permission-model/domain/funnels"
+	"github.com/adhikag24/policy-based-permission-model/domain/groups"
 	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/adhikag24/policy-based-permission-model/domain/roles"
 	"github.com/adhikag24/policy-based-permission-model/http"
 	handlersblogs "github.com/adhikag24/policy-based-permission-model/http/handlers/blogs"
 	handlersfunnels "github.com/adhikag24/policy-based-permission-model/http/handlers/funnels"
+	handlersgroups "github.com/adhikag24/policy-based-permission-model/http/handlers/groups"
 	handlerspolicies "github.com/adhikag24/policy-based-permission-model/http/handlers/policies"
+	handlersroles "github.com/adhikag24/policy-based-permission-model/http/handlers/roles"
 	"github.com/adhikag24/policy-based-permission-model/infrastructure/mysql"
+	mysqlgroups "github.com/adhikag24/policy-based-permission-model/infrastructure/mysql/groups"
 	mysqlpolicies "github.com/adhikag24/policy-based-permission-model/infrastructure/mysql/policies"
+	mysqlroles "github.com/adhikag24/policy-based-permission-model/infrastructure/mysql/roles"
 	"github.com/adhikag24/policy-based-permission-model/utils"
 	"github.com/labstack/echo/v5"
 )
@@ -20,6 +28,9 @@ func main() {
 	utils.LoadEnv()
 
 	e := echo.New()
+	// Conditions on ip see the peer address. Behind a proxy, use echo.ExtractIPFromXFFHeader with the
+	// proxy ranges trusted instead; trusting forwarding headers from anyone lets callers pick their ip.
+	e.IPExtractor = echo.ExtractIPDirect()
 
 	config := initializeConfig()
 
@@ -28,10 +39,37 @@ func main() {
 		panic("failed to connect database")
 	}
 
+	actionRegistry := policies.NewActionRegistry()
+	actionRegistry.Imply(policies.ActionManage, policies.ActionWrite, policies.ActionDelete, policies.ActionPublish)
+	blogs.RegisterActions(actionRegistry)
+	funnels.RegisterActions(actionRegistry)
+
+	resourceRegistry := policies.NewResourceRegistry()
+	blogs.RegisterResources(resourceRegistry)
+	funnels.RegisterResources(resourceRegistry)
+
+	rolesRepository := mysqlroles.NewRepository(db)
+	rolesService := roles.NewService(rolesRepository,
+		roles.WithActionRegistry(actionRegistry),
+		roles.WithResourceRegistry(resourceRegistry),
+	)
+	rolesHandler := handlersroles.NewHandler(rolesService)
+
+	groupsRepository := mysqlgroups.NewRepository(db)
+	groupsService := groups.NewService(groupsRepository)
+	groupsHandler := handlersgroups.NewHandler(groupsService)
+
 	policiesRepository := mysqlpolicies.NewRepository(db)
-	policiesService := policies.NewService(policiesRepository)
+	policiesService := policies.NewService(policiesRepository,
+		policies.WithActionRegistry(actionRegistry),
+		policies.WithResourceRegistry(resourceRegistry),
+		policies.WithGrantProvider(rolesService),
+		policies.WithGroupResolver(groupsService),
+	)
 	policiesHandler := handlerspolicies.NewHandler(policiesService)
 
+	go policies.NewSweeper(policiesRepository, time.Minute).Run(context.Background())
+
 	funnelsServuce := funnels.NewService(policiesService)
 	funnelsHandler := handlersfunnels.NewHandler(funnelsServuce)
 
@@ -42,6 +80,8 @@ func main() {
 		Policies: policiesHandler,
 		Funnels:  funnelsHandler,
 		Blogs:    blogsHandler,
+		Roles:    rolesHandler,
+		Groups:   groupsHandler,
 	})
 
 	slog.Info("starting server on :8080")
@@ -6,6 +6,7 @@ type WriteBlogPageRequest struct {
 	Title        string
 	Content      string
 	PageID       string
+	Attributes   map[string]string // Evaluated by policy conditions. E.g., ip
 }
 
 type WriteBlogSettingsRequest struct {
@@ -14,6 +15,7 @@ type WriteBlogSettingsRequest struct {
 	BlogID       string
 	Title        string
 	Content      string
+	Attributes   map[string]string
 }
 
 type ReadBlogPageRequest struct {
@@ -21,10 +23,20 @@ type ReadBlogPageRequest struct {
 	TeamMemberID int64
 	BlogID       string
 	PageID       string
+	Attributes   map[string]string
 }
 
 type ReadBlogSettingsRequest struct {
 	AccountID    int64
 	TeamMemberID int64
 	BlogID       string
+	Attributes   map[string]string
+}
+
+type PublishBlogPageRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+	BlogID       string
+	PageID       string
+	Attributes   map[string]string
 }
@@ -2,7 +2,6 @@ package blogs
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/adhikag24/policy-based-permission-model/domain/policies"
 )
@@ -12,6 +11,7 @@ type Service interface {
 	ReadBlogPage(ctx context.Context, request *ReadBlogPageRequest) error
 	ReadBlogSettings(ctx context.Context, request *ReadBlogSettingsRequest) error
 	WriteBlogSettings(ctx context.Context, request *WriteBlogSettingsRequest) error
+	PublishBlogPage(ctx context.Context, request *PublishBlogPageRequest) error
 }
 
 type service struct {
@@ -24,53 +24,111 @@ func NewService(policiesService policies.Service) Service {
 	}
 }
 
+// RegisterActions registers the blog specific actions, on top of read and write.
+func RegisterActions(registry *policies.ActionRegistry) {
+	registry.Register("blogs",
+		policies.ActionDelete,
+		policies.ActionPublish,
+		policies.ActionShare,
+		policies.ActionManageSettings,
+		policies.ActionManage,
+	)
+}
+
+// Resource templates of the blogs module.
+var (
+	BlogResource = policies.ResourceTemplate{
+		Template: "blogs/{blogID}",
+		Actions: []policies.Action{
+			policies.ActionRead, policies.ActionWrite, policies.ActionDelete, policies.ActionPublish,
+			policies.ActionShare, policies.ActionManageSettings, policies.ActionManage,
+		},
+	}
+	BlogPageResource = policies.ResourceTemplate{
+		Template: "blogs/{blogID}/pages/{pageID}",
+		Actions: []policies.Action{
+			policies.ActionRead, policies.ActionWrite, policies.ActionDelete, policies.ActionPublish,
+			policies.ActionShare, policies.ActionManage,
+		},
+	}
+	BlogSettingsResource = policies.ResourceTemplate{
+		Template: "blogs/{blogID}/settings",
+		Actions: []policies.Action{
+			policies.ActionRead, policies.ActionWrite, policies.ActionManageSettings, policies.ActionManage,
+		},
+	}
+)
+
+// RegisterResources registers the blog resource templates.
+func RegisterResources(registry *policies.ResourceRegistry) {
+	registry.Register(BlogResource, BlogPageResource, BlogSettingsResource)
+}
+
 func (s *service) ReadBlogSettings(ctx context.Context, request *ReadBlogSettingsRequest) error {
-	if isPermitted := s.policiesService.CheckPermission(ctx, &policies.CheckPermissionRequest{
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
 		AccountID:    request.AccountID,
 		TeamMemberID: request.TeamMemberID,
-		Resource:     fmt.Sprintf("blogs/%s/settings", request.BlogID),
+		Resource:     BlogSettingsResource.Build(request.BlogID),
 		Action:       policies.ActionRead,
-	}); !isPermitted {
-		return ErrPermissionDenied
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (s *service) WriteBlogPage(ctx context.Context, request *WriteBlogPageRequest) error {
-	if isPermitted := s.policiesService.CheckPermission(ctx, &policies.CheckPermissionRequest{
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
 		AccountID:    request.AccountID,
 		TeamMemberID: request.TeamMemberID,
-		Resource:     fmt.Sprintf("blogs/%s", request.PageID), // Check if user has permission to write this blog page.
+		Resource:     BlogResource.Build(request.PageID), // Check if user has permission to write this blog page.
 		Action:       policies.ActionWrite,
-	}); !isPermitted {
-		return ErrPermissionDenied
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (s *service) WriteBlogSettings(ctx context.Context, request *WriteBlogSettingsRequest) error {
-	if isPermitted := s.policiesService.CheckPermission(ctx, &policies.CheckPermissionRequest{
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
 		AccountID:    request.AccountID,
 		TeamMemberID: request.TeamMemberID,
-		Resource:     fmt.Sprintf("blogs/%s/settings", request.BlogID),
+		Resource:     BlogSettingsResource.Build(request.BlogID),
 		Action:       policies.ActionWrite,
-	}); !isPermitted {
-		return ErrPermissionDenied
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (s *service) ReadBlogPage(ctx context.Context, request *ReadBlogPageRequest) error {
-	if isPermitted := s.policiesService.CheckPermission(ctx, &policies.CheckPermissionRequest{
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
 		AccountID:    request.AccountID,
 		TeamMemberID: request.TeamMemberID,
-		Resource:     fmt.Sprintf("blogs/%s/pages/%s", request.BlogID, request.PageID), // Simulates multiple identifiers in resource.
+		Resource:     BlogPageResource.Build(request.BlogID, request.PageID), // Simulates multiple identifiers in resource.
 		Action:       policies.ActionRead,
-	}); !isPermitted {
-		return ErrPermissionDenied
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) PublishBlogPage(ctx context.Context, request *PublishBlogPageRequest) error {
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
+		AccountID:    request.AccountID,
+		TeamMemberID: request.TeamMemberID,
+		Resource:     BlogPageResource.Build(request.BlogID, request.PageID),
+		Action:       policies.ActionPublish,
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
 	}
 
 	return nil
@@ -9,16 +9,26 @@ type CreateFunnelRequest struct {
 	AccountID    int64
 	TeamMemberID int64
 	Name         string
+	Attributes   map[string]string // Evaluated by policy conditions. E.g., ip
 }
 
 type GetFunnelRequest struct {
 	AccountID    int64
 	TeamMemberID int64
 	FunnelID     string
+	Attributes   map[string]string
 }
 
 type EditFunnelRequest struct {
 	AccountID    int64
 	TeamMemberID int64
 	FunnelID     string
+	Attributes   map[string]string
+}
+
+type DeleteFunnelRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+	FunnelID     string
+	Attributes   map[string]string
 }
@@ -1,7 +1,8 @@
 package funnels
 
-import "errors"
+import "github.com/adhikag24/policy-based-permission-model/domain/policies"
 
 var (
-	ErrPermissionDenied = errors.New("permission denied")
+	ErrPermissionDenied           = policies.ErrPermissionDenied
+	ErrPermissionCheckUnavailable = policies.ErrPermissionCheckUnavailable
 )
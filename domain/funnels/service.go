@@ -10,6 +10,7 @@ type Service interface {
 	CreateFunnel(ctx context.Context, request *CreateFunnelRequest) error
 	EditFunnel(ctx context.Context, request *EditFunnelRequest) error
 	GetFunnel(ctx context.Context, request *GetFunnelRequest) (*Funnel, error)
+	DeleteFunnel(ctx context.Context, request *DeleteFunnelRequest) error
 }
 
 type service struct {
@@ -22,40 +23,67 @@ func NewService(policiesService policies.Service) Service {
 	}
 }
 
+// RegisterActions registers the funnel specific actions, on top of read and write.
+func RegisterActions(registry *policies.ActionRegistry) {
+	registry.Register("funnels",
+		policies.ActionDelete,
+		policies.ActionPublish,
+		policies.ActionShare,
+		policies.ActionManage,
+	)
+}
+
+// FunnelResource is the resource template of the funnels module.
+var FunnelResource = policies.ResourceTemplate{
+	Template: "funnels/{funnelID}",
+	Actions: []policies.Action{
+		policies.ActionRead, policies.ActionWrite, policies.ActionDelete, policies.ActionPublish,
+		policies.ActionShare, policies.ActionManage,
+	},
+}
+
+// RegisterResources registers the funnel resource templates.
+func RegisterResources(registry *policies.ResourceRegistry) {
+	registry.Register(FunnelResource)
+}
+
 func (s *service) CreateFunnel(ctx context.Context, request *CreateFunnelRequest) error {
-	if isPermitted := s.policiesService.CheckPermission(ctx, &policies.CheckPermissionRequest{
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
 		AccountID:    request.AccountID,
 		TeamMemberID: request.TeamMemberID,
-		Resource:     "funnels/*",
+		Resource:     FunnelResource.Build("*"),
 		Action:       policies.ActionWrite,
-	}); !isPermitted {
-		return ErrPermissionDenied
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (s *service) EditFunnel(ctx context.Context, request *EditFunnelRequest) error {
-	if isPermitted := s.policiesService.CheckPermission(ctx, &policies.CheckPermissionRequest{
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
 		AccountID:    request.AccountID,
 		TeamMemberID: request.TeamMemberID,
-		Resource:     "funnels/" + request.FunnelID,
+		Resource:     FunnelResource.Build(request.FunnelID),
 		Action:       policies.ActionWrite,
-	}); !isPermitted {
-		return ErrPermissionDenied
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (s *service) GetFunnel(ctx context.Context, request *GetFunnelRequest) (*Funnel, error) {
-	if isPermitted := s.policiesService.CheckPermission(ctx, &policies.CheckPermissionRequest{
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
 		AccountID:    request.AccountID,
 		TeamMemberID: request.TeamMemberID,
-		Resource:     "funnels/" + request.FunnelID,
+		Resource:     FunnelResource.Build(request.FunnelID),
 		Action:       policies.ActionRead,
-	}); !isPermitted {
-		return nil, ErrPermissionDenied
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return nil, err
 	}
 
 	return &Funnel{
@@ -63,3 +91,17 @@ func (s *service) GetFunnel(ctx context.Context, request *GetFunnelRequest) (*Fu
 		Name:     "Demo Funnel",
 	}, nil
 }
+
+func (s *service) DeleteFunnel(ctx context.Context, request *DeleteFunnelRequest) error {
+	if err := policies.Authorize(ctx, s.policiesService, &policies.CheckPermissionRequest{
+		AccountID:    request.AccountID,
+		TeamMemberID: request.TeamMemberID,
+		Resource:     FunnelResource.Build(request.FunnelID),
+		Action:       policies.ActionDelete,
+		Attributes:   request.Attributes,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
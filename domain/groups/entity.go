@@ -0,0 +1,14 @@
+package groups
+
+// Group is a set of team members within an account, such as "Marketing" or "Support".
+type Group struct {
+	ID        int64
+	AccountID int64
+	Name      string
+}
+
+type Membership struct {
+	AccountID    int64
+	GroupID      int64
+	TeamMemberID int64
+}
@@ -0,0 +1,9 @@
+package groups
+
+import "errors"
+
+var (
+	ErrGroupNotFound     = errors.New("group not found")
+	ErrGroupNameRequired = errors.New("group name is required")
+	ErrNotAccountMember  = errors.New("team member does not belong to the account")
+)
@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/groups/repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/groups/repository.go -destination=domain/groups/mocks/mock_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	groups "github.com/adhikag24/policy-based-permission-model/domain/groups"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockRepository) AddMember(ctx context.Context, membership *groups.Membership) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, membership)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockRepositoryMockRecorder) AddMember(ctx, membership any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockRepository)(nil).AddMember), ctx, membership)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, group *groups.Group) (*groups.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, group)
+	ret0, _ := ret[0].(*groups.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, group)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, request *groups.GetGroupRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, request)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(ctx context.Context, request *groups.GetGroupRequest) (*groups.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, request)
+	ret0, _ := ret[0].(*groups.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, request)
+}
+
+// GetByTeamMember mocks base method.
+func (m *MockRepository) GetByTeamMember(ctx context.Context, request *groups.GetByTeamMemberRequest) ([]groups.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTeamMember", ctx, request)
+	ret0, _ := ret[0].([]groups.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTeamMember indicates an expected call of GetByTeamMember.
+func (mr *MockRepositoryMockRecorder) GetByTeamMember(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTeamMember", reflect.TypeOf((*MockRepository)(nil).GetByTeamMember), ctx, request)
+}
+
+// GetMemberIDs mocks base method.
+func (m *MockRepository) GetMemberIDs(ctx context.Context, request *groups.GetGroupRequest) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberIDs", ctx, request)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberIDs indicates an expected call of GetMemberIDs.
+func (mr *MockRepositoryMockRecorder) GetMemberIDs(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberIDs", reflect.TypeOf((*MockRepository)(nil).GetMemberIDs), ctx, request)
+}
+
+// IsAccountMember mocks base method.
+func (m *MockRepository) IsAccountMember(ctx context.Context, request *groups.GetByTeamMemberRequest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccountMember", ctx, request)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccountMember indicates an expected call of IsAccountMember.
+func (mr *MockRepositoryMockRecorder) IsAccountMember(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccountMember", reflect.TypeOf((*MockRepository)(nil).IsAccountMember), ctx, request)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, accountID int64) ([]groups.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, accountID)
+	ret0, _ := ret[0].([]groups.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, accountID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockRepository) RemoveMember(ctx context.Context, membership *groups.Membership) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, membership)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockRepositoryMockRecorder) RemoveMember(ctx, membership any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockRepository)(nil).RemoveMember), ctx, membership)
+}
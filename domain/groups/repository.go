@@ -0,0 +1,28 @@
+package groups
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, group *Group) (*Group, error)
+	Delete(ctx context.Context, request *GetGroupRequest) error
+	Get(ctx context.Context, request *GetGroupRequest) (*Group, error)
+	List(ctx context.Context, accountID int64) ([]Group, error)
+	AddMember(ctx context.Context, membership *Membership) error
+	RemoveMember(ctx context.Context, membership *Membership) error
+	GetByTeamMember(ctx context.Context, request *GetByTeamMemberRequest) ([]Group, error)
+	GetMemberIDs(ctx context.Context, request *GetGroupRequest) ([]int64, error)
+	// IsAccountMember checks the team member against account_team_members.
+	IsAccountMember(ctx context.Context, request *GetByTeamMemberRequest) (bool, error)
+}
+
+// Retreive a single group scoped to its account.
+type GetGroupRequest struct {
+	AccountID int64
+	GroupID   int64
+}
+
+// Retreive groups a team member belongs to within an account.
+type GetByTeamMemberRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+}
@@ -0,0 +1,95 @@
+package groups
+
+import "context"
+
+type Service interface {
+	CreateGroup(ctx context.Context, group *Group) (*Group, error)
+	DeleteGroup(ctx context.Context, request *GetGroupRequest) error
+	ListGroups(ctx context.Context, accountID int64) ([]Group, error)
+	AddMember(ctx context.Context, membership *Membership) error
+	RemoveMember(ctx context.Context, membership *Membership) error
+
+	// GetGroupIDs lets policies.Service expand a team member into its group principals.
+	GetGroupIDs(ctx context.Context, accountID, teamMemberID int64) ([]int64, error)
+	// GetMemberIDs lets policies.Service expand a group principal into its team members.
+	GetMemberIDs(ctx context.Context, accountID, groupID int64) ([]int64, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) CreateGroup(ctx context.Context, group *Group) (*Group, error) {
+	if group.Name == "" {
+		return nil, ErrGroupNameRequired
+	}
+
+	return s.repo.Create(ctx, group)
+}
+
+func (s *service) DeleteGroup(ctx context.Context, request *GetGroupRequest) error {
+	if _, err := s.repo.Get(ctx, request); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, request)
+}
+
+func (s *service) ListGroups(ctx context.Context, accountID int64) ([]Group, error) {
+	return s.repo.List(ctx, accountID)
+}
+
+func (s *service) AddMember(ctx context.Context, membership *Membership) error {
+	if _, err := s.repo.Get(ctx, &GetGroupRequest{
+		AccountID: membership.AccountID,
+		GroupID:   membership.GroupID,
+	}); err != nil {
+		return err
+	}
+
+	// Only members of the account can join its groups.
+	isMember, err := s.repo.IsAccountMember(ctx, &GetByTeamMemberRequest{
+		AccountID:    membership.AccountID,
+		TeamMemberID: membership.TeamMemberID,
+	})
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrNotAccountMember
+	}
+
+	return s.repo.AddMember(ctx, membership)
+}
+
+func (s *service) RemoveMember(ctx context.Context, membership *Membership) error {
+	return s.repo.RemoveMember(ctx, membership)
+}
+
+func (s *service) GetGroupIDs(ctx context.Context, accountID, teamMemberID int64) ([]int64, error) {
+	groups, err := s.repo.GetByTeamMember(ctx, &GetByTeamMemberRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]int64, 0, len(groups))
+	for _, group := range groups {
+		groupIDs = append(groupIDs, group.ID)
+	}
+
+	return groupIDs, nil
+}
+
+func (s *service) GetMemberIDs(ctx context.Context, accountID, groupID int64) ([]int64, error) {
+	return s.repo.GetMemberIDs(ctx, &GetGroupRequest{
+		AccountID: accountID,
+		GroupID:   groupID,
+	})
+}
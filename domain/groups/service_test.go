@@ -0,0 +1,107 @@
+package groups_test
+
+import (
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/groups"
+	mockRepository "github.com/adhikag24/policy-based-permission-model/domain/groups/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type test struct {
+	mockRepository *mockRepository.MockRepository
+}
+
+func setup(ctrl *gomock.Controller) *test {
+	return &test{
+		mockRepository: mockRepository.NewMockRepository(ctrl),
+	}
+}
+
+func TestAddMember(t *testing.T) {
+	t.Run("Successfully adds account member to group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		membership := &groups.Membership{AccountID: 100, GroupID: 1, TeamMemberID: 200}
+		test.mockRepository.EXPECT().Get(gomock.Any(), &groups.GetGroupRequest{
+			AccountID: 100,
+			GroupID:   1,
+		}).Return(&groups.Group{ID: 1, AccountID: 100, Name: "Marketing"}, nil)
+		test.mockRepository.EXPECT().IsAccountMember(gomock.Any(), &groups.GetByTeamMemberRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+		}).Return(true, nil)
+		test.mockRepository.EXPECT().AddMember(gomock.Any(), membership).Return(nil)
+		service := groups.NewService(test.mockRepository)
+
+		err := service.AddMember(t.Context(), membership)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Rejects team member outside the account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).
+			Return(&groups.Group{ID: 1, AccountID: 100, Name: "Marketing"}, nil)
+		test.mockRepository.EXPECT().IsAccountMember(gomock.Any(), gomock.Any()).Return(false, nil)
+		service := groups.NewService(test.mockRepository)
+
+		err := service.AddMember(t.Context(), &groups.Membership{AccountID: 100, GroupID: 1, TeamMemberID: 300})
+
+		assert.ErrorIs(t, err, groups.ErrNotAccountMember)
+	})
+}
+
+func TestDeleteGroup(t *testing.T) {
+	t.Run("Successfully deletes group of the account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		request := &groups.GetGroupRequest{AccountID: 100, GroupID: 1}
+		test.mockRepository.EXPECT().Get(gomock.Any(), request).Return(&groups.Group{ID: 1, AccountID: 100, Name: "Marketing"}, nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), request).Return(nil)
+		service := groups.NewService(test.mockRepository)
+
+		err := service.DeleteGroup(t.Context(), request)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Leaves group of another account untouched", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &groups.GetGroupRequest{
+			AccountID: 100,
+			GroupID:   2,
+		}).Return(nil, groups.ErrGroupNotFound)
+		service := groups.NewService(test.mockRepository)
+
+		err := service.DeleteGroup(t.Context(), &groups.GetGroupRequest{AccountID: 100, GroupID: 2})
+
+		assert.ErrorIs(t, err, groups.ErrGroupNotFound)
+	})
+}
+
+func TestGetGroupIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	test := setup(ctrl)
+	test.mockRepository.EXPECT().GetByTeamMember(gomock.Any(), &groups.GetByTeamMemberRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+	}).Return([]groups.Group{
+		{ID: 1, AccountID: 100, Name: "Marketing"},
+		{ID: 2, AccountID: 100, Name: "Support"},
+	}, nil)
+	service := groups.NewService(test.mockRepository)
+
+	groupIDs, err := service.GetGroupIDs(t.Context(), 100, 200)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, groupIDs)
+}
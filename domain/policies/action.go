@@ -0,0 +1,155 @@
+package policies
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// AnyResourceType registers an action for every resource type.
+const AnyResourceType = "*"
+
+// ActionRegistry holds the actions allowed per resource type, where the resource type
+// is the first segment of a resource. E.g., blogs for blogs/123/settings.
+// It also holds implications between actions. E.g., write implies read.
+type ActionRegistry struct {
+	mu      sync.RWMutex
+	actions map[string]map[Action]bool
+	implies map[Action][]Action
+}
+
+// NewActionRegistry returns a registry where read and write are allowed on every resource type,
+// and write implies read.
+func NewActionRegistry() *ActionRegistry {
+	registry := &ActionRegistry{
+		actions: make(map[string]map[Action]bool),
+		implies: make(map[Action][]Action),
+	}
+	registry.Register(AnyResourceType, ActionRead, ActionWrite)
+	registry.Imply(ActionWrite, ActionRead)
+	return registry
+}
+
+// Imply declares that a grant on action also grants each of implied. Implications are transitive.
+func (r *ActionRegistry) Imply(action Action, implied ...Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, impliedAction := range implied {
+		if !slices.Contains(r.implies[action], impliedAction) {
+			r.implies[action] = append(r.implies[action], impliedAction)
+		}
+	}
+}
+
+// Implies reports whether a grant on action also grants target, directly or transitively.
+func (r *ActionRegistry) Implies(action, target Action) bool {
+	return slices.Contains(r.ImplyingActions(target), action)
+}
+
+// ImplyingActions returns the action itself followed by every action that implies it.
+// E.g., read -> [read, manage, write] when manage implies write and write implies read.
+func (r *ActionRegistry) ImplyingActions(action Action) []Action {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	visited := map[Action]bool{action: true}
+	queue := []Action{action}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for candidate, impliedActions := range r.implies {
+			if !visited[candidate] && slices.Contains(impliedActions, current) {
+				visited[candidate] = true
+				queue = append(queue, candidate)
+			}
+		}
+	}
+
+	implying := make([]Action, 0, len(visited)-1)
+	for candidate := range visited {
+		if candidate != action {
+			implying = append(implying, candidate)
+		}
+	}
+	slices.Sort(implying)
+
+	return append([]Action{action}, implying...)
+}
+
+func (r *ActionRegistry) Register(resourceType string, actions ...Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.actions[resourceType] == nil {
+		r.actions[resourceType] = make(map[Action]bool)
+	}
+	for _, action := range actions {
+		r.actions[resourceType][action] = true
+	}
+}
+
+// IsRegistered reports whether the action is allowed on the resource.
+// The root resource * accepts any action registered on some resource type.
+func (r *ActionRegistry) IsRegistered(resource string, action Action) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.actions[AnyResourceType][action] {
+		return true
+	}
+
+	resourceType := getResourceType(resource)
+	if resourceType != AnyResourceType {
+		return r.actions[resourceType][action]
+	}
+
+	for _, actions := range r.actions {
+		if actions[action] {
+			return true
+		}
+	}
+	return false
+}
+
+// Actions returns the actions allowed on a resource type, including the ones allowed everywhere.
+func (r *ActionRegistry) Actions(resourceType string) []Action {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var actions []Action
+	for action := range r.actions[AnyResourceType] {
+		actions = append(actions, action)
+	}
+	if resourceType != AnyResourceType {
+		for action := range r.actions[resourceType] {
+			if !r.actions[AnyResourceType][action] {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+// AllActions returns every registered action, sorted.
+func (r *ActionRegistry) AllActions() []Action {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var actions []Action
+	for _, registered := range r.actions {
+		for action := range registered {
+			if !slices.Contains(actions, action) {
+				actions = append(actions, action)
+			}
+		}
+	}
+	slices.Sort(actions)
+	return actions
+}
+
+func getResourceType(resource string) string {
+	resourceType, _, _ := strings.Cut(strings.TrimPrefix(resource, "/"), "/")
+	return resourceType
+}
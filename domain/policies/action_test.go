@@ -0,0 +1,56 @@
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionRegistry(t *testing.T) {
+	registry := policies.NewActionRegistry()
+	registry.Register("blogs", policies.ActionPublish, policies.ActionManageSettings)
+
+	tests := []struct {
+		name     string
+		resource string
+		action   policies.Action
+		want     bool
+	}{
+		{name: "read is registered everywhere", resource: "funnels/1", action: policies.ActionRead, want: true},
+		{name: "write is registered everywhere", resource: "blogs/*", action: policies.ActionWrite, want: true},
+		{name: "registered action on its resource type", resource: "blogs/1/pages/2", action: policies.ActionPublish, want: true},
+		{name: "leading slash is ignored", resource: "/blogs/1", action: policies.ActionManageSettings, want: true},
+		{name: "registered action on another resource type", resource: "funnels/1", action: policies.ActionPublish, want: false},
+		{name: "unknown action", resource: "blogs/1", action: "fly", want: false},
+		{name: "root resource accepts any registered action", resource: "*", action: policies.ActionPublish, want: true},
+		{name: "root resource rejects unknown action", resource: "*", action: "fly", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, registry.IsRegistered(tt.resource, tt.action))
+		})
+	}
+}
+
+func TestActionRegistryImplications(t *testing.T) {
+	registry := policies.NewActionRegistry()
+	registry.Imply(policies.ActionManage, policies.ActionWrite, policies.ActionDelete, policies.ActionPublish)
+
+	assert.Equal(t, []policies.Action{policies.ActionRead, policies.ActionManage, policies.ActionWrite}, registry.ImplyingActions(policies.ActionRead))
+	assert.Equal(t, []policies.Action{policies.ActionDelete, policies.ActionManage}, registry.ImplyingActions(policies.ActionDelete))
+	assert.Equal(t, []policies.Action{policies.ActionManage}, registry.ImplyingActions(policies.ActionManage))
+
+	assert.True(t, registry.Implies(policies.ActionManage, policies.ActionRead))
+	assert.True(t, registry.Implies(policies.ActionRead, policies.ActionRead))
+	assert.False(t, registry.Implies(policies.ActionRead, policies.ActionWrite))
+}
+
+func TestActionRegistryAllActions(t *testing.T) {
+	registry := policies.NewActionRegistry()
+	registry.Register("blogs", policies.ActionPublish, policies.ActionWrite)
+	registry.Register("funnels", policies.ActionPublish, policies.ActionDelete)
+
+	assert.Equal(t, []policies.Action{policies.ActionDelete, policies.ActionPublish, policies.ActionRead, policies.ActionWrite}, registry.AllActions())
+}
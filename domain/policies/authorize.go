@@ -0,0 +1,35 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Authorize guards an operation with a permission check, logging why a member was denied.
+// It returns ErrPermissionDenied, or an error wrapping ErrPermissionCheckUnavailable when the check failed.
+func Authorize(ctx context.Context, service Service, request *CheckPermissionRequest) error {
+	decision, err := service.CheckPermission(ctx, request)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check permission", "resource", request.Resource, "action", request.Action, "error", err)
+		if errors.Is(err, ErrPermissionCheckUnavailable) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", ErrPermissionCheckUnavailable, err)
+	}
+
+	if !decision.Allowed {
+		slog.InfoContext(ctx, "permission denied",
+			"account_id", request.AccountID,
+			"team_member_id", request.TeamMemberID,
+			"resource", request.Resource,
+			"action", request.Action,
+			"reason", decision.Reason,
+			"policy_ids", decision.PolicyIDs,
+		)
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package policies_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorize(t *testing.T) {
+	request := &policies.CheckPermissionRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+		Resource:     "blogs/1",
+		Action:       policies.ActionWrite,
+	}
+
+	t.Run("allows a permitted request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		assert.NoError(t, policies.Authorize(t.Context(), service, request))
+	})
+
+	t.Run("denies a request no policy grants", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+		service := policies.NewService(test.mockRepository)
+
+		assert.ErrorIs(t, policies.Authorize(t.Context(), service, request), policies.ErrPermissionDenied)
+	})
+
+	t.Run("tells a failed check apart from a denial", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+		service := policies.NewService(test.mockRepository)
+
+		err := policies.Authorize(t.Context(), service, request)
+
+		assert.ErrorIs(t, err, policies.ErrPermissionCheckUnavailable)
+		assert.NotErrorIs(t, err, policies.ErrPermissionDenied)
+	})
+}
@@ -0,0 +1,371 @@
+package policies
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Condition is a parsed policy condition expression.
+//
+// Grammar:
+//
+//	expr       := and ("||" and)*
+//	and        := unary ("&&" unary)*
+//	unary      := "!" unary | "(" expr ")" | comparison
+//	comparison := attribute operator value
+//	operator   := "==" | "!=" | "<" | "<=" | ">" | ">=" | "in"
+//
+// Values are either quoted strings or bare words. E.g.,
+// time >= 09:00 && time < 18:00 && ip in "10.0.0.0/8" && member.department == "marketing"
+//
+// A condition that depends on a missing or malformed attribute cannot be evaluated and returns
+// ErrUnevaluableCondition, unless the rest of the expression decides it. E.g., a true side of ||.
+type Condition interface {
+	Evaluate(attributes map[string]string) (bool, error)
+}
+
+// Attribute keys with built-in meaning.
+const (
+	AttributeTime = "time" // Time of day formatted as HH:MM.
+	AttributeIP   = "ip"   // Source IP address of the request.
+)
+
+// ConditionError is returned when a condition expression cannot be parsed.
+type ConditionError struct {
+	Expression string
+	Offset     int
+	Message    string
+}
+
+func (e *ConditionError) Error() string {
+	return fmt.Sprintf("invalid condition at offset %d: %s", e.Offset, e.Message)
+}
+
+func (e *ConditionError) Unwrap() error {
+	return ErrInvalidCondition
+}
+
+// ParseCondition parses a condition expression. An empty expression yields a nil condition.
+func ParseCondition(expression string) (Condition, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeCondition(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &conditionParser{expression: expression, tokens: tokens}
+	condition, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorAt(tok, fmt.Sprintf("unexpected %q", tok.text))
+	}
+
+	return condition, nil
+}
+
+type conditionTokenKind int
+
+const (
+	tokenEOF conditionTokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLeftParen
+	tokenRightParen
+)
+
+type conditionToken struct {
+	kind   conditionTokenKind
+	text   string
+	offset int
+}
+
+func tokenizeCondition(expression string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	for i := 0; i < len(expression); {
+		ch := expression[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '(':
+			tokens = append(tokens, conditionToken{kind: tokenLeftParen, text: "(", offset: i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, conditionToken{kind: tokenRightParen, text: ")", offset: i})
+			i++
+		case strings.HasPrefix(expression[i:], "&&"):
+			tokens = append(tokens, conditionToken{kind: tokenAnd, text: "&&", offset: i})
+			i += 2
+		case strings.HasPrefix(expression[i:], "||"):
+			tokens = append(tokens, conditionToken{kind: tokenOr, text: "||", offset: i})
+			i += 2
+		case strings.HasPrefix(expression[i:], "=="), strings.HasPrefix(expression[i:], "!="),
+			strings.HasPrefix(expression[i:], "<="), strings.HasPrefix(expression[i:], ">="):
+			tokens = append(tokens, conditionToken{kind: tokenOperator, text: expression[i : i+2], offset: i})
+			i += 2
+		case ch == '<' || ch == '>':
+			tokens = append(tokens, conditionToken{kind: tokenOperator, text: string(ch), offset: i})
+			i++
+		case ch == '!':
+			tokens = append(tokens, conditionToken{kind: tokenNot, text: "!", offset: i})
+			i++
+		case ch == '"':
+			end := strings.IndexByte(expression[i+1:], '"')
+			if end < 0 {
+				return nil, &ConditionError{Expression: expression, Offset: i, Message: "unterminated string"}
+			}
+			tokens = append(tokens, conditionToken{kind: tokenString, text: expression[i+1 : i+1+end], offset: i})
+			i += end + 2
+		case isConditionWordChar(rune(ch)):
+			start := i
+			for i < len(expression) && isConditionWordChar(rune(expression[i])) {
+				i++
+			}
+			word := expression[start:i]
+			kind := tokenWord
+			if word == "in" {
+				kind = tokenOperator
+			}
+			tokens = append(tokens, conditionToken{kind: kind, text: word, offset: start})
+		default:
+			return nil, &ConditionError{Expression: expression, Offset: i, Message: fmt.Sprintf("unexpected character %q", ch)}
+		}
+	}
+
+	return append(tokens, conditionToken{kind: tokenEOF, offset: len(expression)}), nil
+}
+
+func isConditionWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.:/-*", r)
+}
+
+type conditionParser struct {
+	expression string
+	tokens     []conditionToken
+	position   int
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.position]
+}
+
+func (p *conditionParser) next() conditionToken {
+	tok := p.tokens[p.position]
+	if tok.kind != tokenEOF {
+		p.position++
+	}
+	return tok
+}
+
+func (p *conditionParser) errorAt(tok conditionToken, message string) error {
+	return &ConditionError{Expression: p.expression, Offset: tok.offset, Message: message}
+}
+
+func (p *conditionParser) parseOr() (Condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCondition{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (Condition, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andCondition{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (Condition, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{operand: operand}, nil
+	case tokenLeftParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, p.errorAt(closing, "expected )")
+		}
+		return inner, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *conditionParser) parseComparison() (Condition, error) {
+	attribute := p.next()
+	if attribute.kind != tokenWord {
+		return nil, p.errorAt(attribute, "expected attribute name")
+	}
+
+	operator := p.next()
+	if operator.kind != tokenOperator {
+		return nil, p.errorAt(operator, "expected comparison operator")
+	}
+
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, p.errorAt(value, "expected value")
+	}
+
+	comparison := comparisonCondition{attribute: attribute.text, operator: operator.text, value: value.text}
+	if operator.text == "in" {
+		prefix, err := netip.ParsePrefix(value.text)
+		if err != nil {
+			return nil, p.errorAt(value, "expected CIDR range after in")
+		}
+		comparison.prefix = prefix
+	}
+
+	return comparison, nil
+}
+
+type andCondition struct{ left, right Condition }
+
+func (c andCondition) Evaluate(attributes map[string]string) (bool, error) {
+	left, leftErr := c.left.Evaluate(attributes)
+	if leftErr == nil && !left {
+		return false, nil
+	}
+	right, rightErr := c.right.Evaluate(attributes)
+	if rightErr == nil && !right {
+		return false, nil
+	}
+	if err := cmp.Or(leftErr, rightErr); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+type orCondition struct{ left, right Condition }
+
+func (c orCondition) Evaluate(attributes map[string]string) (bool, error) {
+	left, leftErr := c.left.Evaluate(attributes)
+	if leftErr == nil && left {
+		return true, nil
+	}
+	right, rightErr := c.right.Evaluate(attributes)
+	if rightErr == nil && right {
+		return true, nil
+	}
+	if err := cmp.Or(leftErr, rightErr); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+type notCondition struct{ operand Condition }
+
+func (c notCondition) Evaluate(attributes map[string]string) (bool, error) {
+	operand, err := c.operand.Evaluate(attributes)
+	if err != nil {
+		return false, err
+	}
+	return !operand, nil
+}
+
+type comparisonCondition struct {
+	attribute string
+	operator  string
+	value     string
+	prefix    netip.Prefix // Only set for the in operator.
+}
+
+func (c comparisonCondition) Evaluate(attributes map[string]string) (bool, error) {
+	actual, ok := attributes[c.attribute]
+	if !ok {
+		return false, fmt.Errorf("%w: missing attribute %s", ErrUnevaluableCondition, c.attribute)
+	}
+
+	if c.operator == "in" {
+		addr, err := netip.ParseAddr(actual)
+		if err != nil {
+			return false, fmt.Errorf("%w: attribute %s is not an IP address", ErrUnevaluableCondition, c.attribute)
+		}
+		return c.prefix.Contains(addr), nil
+	}
+
+	order := compareConditionValues(actual, c.value)
+	switch c.operator {
+	case "==":
+		return order == 0, nil
+	case "!=":
+		return order != 0, nil
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	case ">=":
+		return order >= 0, nil
+	}
+
+	return false, nil
+}
+
+// compareConditionValues compares numerically, then as time of day, then as plain strings.
+func compareConditionValues(actual, expected string) int {
+	if a, err := strconv.ParseFloat(actual, 64); err == nil {
+		if b, err := strconv.ParseFloat(expected, 64); err == nil {
+			return compareOrdered(a, b)
+		}
+	}
+
+	if a, err := time.Parse("15:04", actual); err == nil {
+		if b, err := time.Parse("15:04", expected); err == nil {
+			return a.Compare(b)
+		}
+	}
+
+	return strings.Compare(actual, expected)
+}
+
+func compareOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
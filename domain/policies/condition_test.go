@@ -0,0 +1,121 @@
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		attributes map[string]string
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "time window inside",
+			expression: "time >= 09:00 && time < 18:00",
+			attributes: map[string]string{"time": "10:30"},
+			want:       true,
+		},
+		{
+			name:       "time window outside",
+			expression: "time >= 09:00 && time < 18:00",
+			attributes: map[string]string{"time": "18:00"},
+			want:       false,
+		},
+		{
+			name:       "ip inside cidr",
+			expression: `ip in "10.0.0.0/8"`,
+			attributes: map[string]string{"ip": "10.1.2.3"},
+			want:       true,
+		},
+		{
+			name:       "ip outside cidr",
+			expression: "ip in 10.0.0.0/8",
+			attributes: map[string]string{"ip": "192.168.1.1"},
+			want:       false,
+		},
+		{
+			name:       "member attribute equality",
+			expression: `member.department == "marketing"`,
+			attributes: map[string]string{"member.department": "marketing"},
+			want:       true,
+		},
+		{
+			name:       "missing attribute cannot be evaluated",
+			expression: `member.department != "support"`,
+			attributes: map[string]string{},
+			wantErr:    policies.ErrUnevaluableCondition,
+		},
+		{
+			name:       "malformed ip cannot be evaluated",
+			expression: `ip in "10.0.0.0/8"`,
+			attributes: map[string]string{"ip": "unknown"},
+			wantErr:    policies.ErrUnevaluableCondition,
+		},
+		{
+			name:       "negated missing attribute cannot be evaluated",
+			expression: `!(ip in "10.0.0.0/8")`,
+			attributes: map[string]string{},
+			wantErr:    policies.ErrUnevaluableCondition,
+		},
+		{
+			name:       "missing attribute decided by the other side of or",
+			expression: `ip in "10.0.0.0/8" || time < 18:00`,
+			attributes: map[string]string{"time": "10:00"},
+			want:       true,
+		},
+		{
+			name:       "missing attribute decided by the other side of and",
+			expression: `ip in "10.0.0.0/8" && time < 18:00`,
+			attributes: map[string]string{"time": "20:00"},
+			want:       false,
+		},
+		{
+			name:       "negation and grouping",
+			expression: `!(level < 3) || member.department == "support"`,
+			attributes: map[string]string{"level": "5"},
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := policies.ParseCondition(tt.expression)
+			assert.NoError(t, err)
+			got, err := condition.Evaluate(tt.attributes)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantOffset int
+	}{
+		{name: "missing value", expression: "time <", wantOffset: 6},
+		{name: "missing operator", expression: "time 18:00", wantOffset: 5},
+		{name: "unbalanced parenthesis", expression: "(time < 18:00", wantOffset: 13},
+		{name: "invalid cidr", expression: "ip in nope", wantOffset: 6},
+		{name: "unterminated string", expression: `team == "support`, wantOffset: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := policies.ParseCondition(tt.expression)
+			assert.ErrorIs(t, err, policies.ErrInvalidCondition)
+
+			var conditionErr *policies.ConditionError
+			if assert.ErrorAs(t, err, &conditionErr) {
+				assert.Equal(t, tt.wantOffset, conditionErr.Offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package policies
+
+// MatchRule describes how a policy resource matched the requested resource.
+type MatchRule string
+
+const (
+	MatchRuleExact      MatchRule = "exact"       // E.g., blogs/1 matches blogs/1
+	MatchRuleWildcard   MatchRule = "wildcard"    // E.g., blogs/* matches blogs/1
+	MatchRuleRoot       MatchRule = "root"        // * matches everything.
+	MatchRuleParentRead MatchRule = "parent-read" // E.g., blogs/1/pages/2 lets the user read blogs/1
+)
+
+// DenialReason explains why a permission check was denied.
+type DenialReason string
+
+const (
+	DenialReasonNoMatchingPolicy DenialReason = "no_matching_policy"
+	DenialReasonExplicitDeny     DenialReason = "explicit_deny"
+	DenialReasonConditionNotMet  DenialReason = "condition_not_met" // An allow matched, but its condition did not hold.
+	DenialReasonPolicyInactive   DenialReason = "policy_inactive"   // An allow matched, but it expired or is not active yet.
+	DenialReasonInvalidResource  DenialReason = "invalid_resource"
+	DenialReasonLookupFailed     DenialReason = "lookup_failed"
+)
+
+// Decision is the outcome of a permission check.
+type Decision struct {
+	Allowed bool
+	// PolicyIDs holds the deny that decided the check, or every allow that matched.
+	// Role-derived grants have no policy ID, see RoleIDs.
+	PolicyIDs []int64
+	RoleIDs   []int64
+	Rule      MatchRule    // How the first deciding policy matched. Empty when nothing matched.
+	Reason    DenialReason // Empty when allowed.
+}
+
+func (d *Decision) addMatch(policy Policy, rule MatchRule) {
+	if d.Rule == "" {
+		d.Rule = rule
+	}
+
+	if policy.RoleID != 0 {
+		d.RoleIDs = append(d.RoleIDs, policy.RoleID)
+		return
+	}
+	d.PolicyIDs = append(d.PolicyIDs, policy.ID)
+}
+
+func deny(reason DenialReason) *Decision {
+	return &Decision{Reason: reason}
+}
@@ -0,0 +1,301 @@
+package policies
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DocumentVersion is the only document version understood by ParseDocument.
+const DocumentVersion = "1"
+
+// Document is an IAM-style policy document, meant to be kept in version control. E.g.,
+//
+//	{
+//	  "version": "1",
+//	  "statements": [
+//	    {"effect": "allow", "actions": ["read", "write"], "resources": ["blogs/*"]},
+//	    {"effect": "deny", "actions": ["write"], "resources": ["blogs/*/settings"]}
+//	  ]
+//	}
+//
+// A document has no principal; it applies to whoever it is imported for.
+type Document struct {
+	Version    string              `json:"version"`
+	Statements []DocumentStatement `json:"statements"`
+}
+
+// DocumentStatement expands into one policy per action and resource.
+type DocumentStatement struct {
+	ID        string     `json:"sid,omitempty"` // Optional, only used to report outcomes.
+	Effect    Effect     `json:"effect"`
+	Actions   []Action   `json:"actions"`
+	Resources []string   `json:"resources"`
+	Condition string     `json:"condition,omitempty"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// DocumentError reports the statement that made a document invalid.
+type DocumentError struct {
+	Statement int // -1 when the document itself is invalid.
+	Message   string
+}
+
+func (e *DocumentError) Error() string {
+	if e.Statement < 0 {
+		return "invalid policy document: " + e.Message
+	}
+	return fmt.Sprintf("invalid policy document: statement %d: %s", e.Statement, e.Message)
+}
+
+func (e *DocumentError) Unwrap() error {
+	return ErrInvalidDocument
+}
+
+// ParseDocument decodes a document strictly, so a typo such as "resource" is not silently ignored.
+// Policy rules, such as registered actions, are only checked on import.
+func ParseDocument(data []byte) (*Document, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var document Document
+	if err := decoder.Decode(&document); err != nil {
+		return nil, &DocumentError{Statement: -1, Message: err.Error()}
+	}
+
+	if document.Version != DocumentVersion {
+		return nil, &DocumentError{Statement: -1, Message: fmt.Sprintf("unsupported version %q", document.Version)}
+	}
+
+	for i, statement := range document.Statements {
+		switch {
+		case statement.Effect != EffectAllow && statement.Effect != EffectDeny:
+			return nil, &DocumentError{Statement: i, Message: "effect must be either allow or deny"}
+		case len(statement.Actions) == 0:
+			return nil, &DocumentError{Statement: i, Message: "actions must not be empty"}
+		case len(statement.Resources) == 0:
+			return nil, &DocumentError{Statement: i, Message: "resources must not be empty"}
+		}
+	}
+
+	return &document, nil
+}
+
+// Policies expands the statement into one policy per action and resource, without a principal.
+func (s DocumentStatement) Policies() []Policy {
+	policies := make([]Policy, 0, len(s.Actions)*len(s.Resources))
+	for _, action := range s.Actions {
+		for _, resource := range s.Resources {
+			policies = append(policies, Policy{
+				Resource:  resource,
+				Action:    action,
+				Effect:    s.Effect,
+				Condition: s.Condition,
+				NotBefore: s.NotBefore,
+				ExpiresAt: s.ExpiresAt,
+			})
+		}
+	}
+	return policies
+}
+
+// NewDocument groups policies into as few statements as possible: policies sharing effect,
+// condition, and validity window are merged, and so are actions granted on the same resources.
+func NewDocument(policies []Policy) *Document {
+	type statementKey struct {
+		effect, condition, notBefore, expiresAt string
+	}
+	type statementGroup struct {
+		template          DocumentStatement
+		resourcesByAction map[Action][]string
+	}
+
+	var keys []statementKey
+	groups := make(map[statementKey]*statementGroup)
+	for _, policy := range policies {
+		effect := policy.Effect
+		if effect == "" {
+			effect = EffectAllow
+		}
+
+		key := statementKey{string(effect), policy.Condition, formatOptionalTime(policy.NotBefore), formatOptionalTime(policy.ExpiresAt)}
+		group, ok := groups[key]
+		if !ok {
+			keys = append(keys, key)
+			group = &statementGroup{
+				template: DocumentStatement{
+					Effect:    effect,
+					Condition: policy.Condition,
+					NotBefore: policy.NotBefore,
+					ExpiresAt: policy.ExpiresAt,
+				},
+				resourcesByAction: make(map[Action][]string),
+			}
+			groups[key] = group
+		}
+		if !slices.Contains(group.resourcesByAction[policy.Action], policy.Resource) {
+			group.resourcesByAction[policy.Action] = append(group.resourcesByAction[policy.Action], policy.Resource)
+		}
+	}
+
+	// Allows come before denies so the document reads top down.
+	slices.SortStableFunc(keys, func(a, b statementKey) int {
+		return strings.Compare(a.effect, b.effect)
+	})
+
+	document := &Document{Version: DocumentVersion, Statements: []DocumentStatement{}}
+	for _, key := range keys {
+		group := groups[key]
+		first := len(document.Statements)
+		for _, action := range slices.Sorted(maps.Keys(group.resourcesByAction)) {
+			resources := group.resourcesByAction[action]
+			slices.Sort(resources)
+
+			i := slices.IndexFunc(document.Statements[first:], func(statement DocumentStatement) bool {
+				return slices.Equal(statement.Resources, resources)
+			})
+			if i >= 0 {
+				document.Statements[first+i].Actions = append(document.Statements[first+i].Actions, action)
+				continue
+			}
+
+			statement := group.template
+			statement.Actions = []Action{action}
+			statement.Resources = resources
+			document.Statements = append(document.Statements, statement)
+		}
+	}
+
+	return document
+}
+
+// ExportDocumentRequest exports the policies targeting the member directly.
+// Group policies and roles are not part of the member's document.
+type ExportDocumentRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+}
+
+// ImportDocumentRequest applies Document to the member, statement by statement, through CreatePolicy.
+type ImportDocumentRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+	Document     *Document
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusCreated   ImportStatus = "created"
+	ImportStatusRedundant ImportStatus = "redundant" // A broader policy already exists, see ErrUserAlreadyHasBroaderPolicy.
+	ImportStatusRejected  ImportStatus = "rejected"  // The policy failed validation, see Err.
+)
+
+// StatementOutcome reports what happened to every policy a statement expanded into.
+type StatementOutcome struct {
+	Statement int    // Index in the document.
+	ID        string // Sid of the statement, if any.
+	Policies  []ImportedPolicy
+}
+
+type ImportedPolicy struct {
+	Resource string
+	Action   Action
+	Status   ImportStatus
+	PolicyID int64 // Only set when created.
+	Err      error // Only set when rejected.
+}
+
+func (s *service) ExportDocument(ctx context.Context, request *ExportDocumentRequest) (*Document, error) {
+	policies, err := s.repo.Get(ctx, &GetPolicyRequest{
+		AccountID:    request.AccountID,
+		TeamMemberID: request.TeamMemberID,
+		Actions:      s.actions.AllActions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Expired policies are awaiting the sweeper; importing them back would be rejected anyway.
+	now := s.now()
+	policies = slices.DeleteFunc(policies, func(policy Policy) bool {
+		return policy.ExpiresAt != nil && !now.Before(*policy.ExpiresAt)
+	})
+
+	return NewDocument(policies), nil
+}
+
+// ImportDocument applies statements in order, like successive CreatePolicy calls: a later, broader
+// statement may replace a policy created by an earlier one. Validation errors are reported per policy;
+// any other error aborts the import in a single transaction, so no policy of the document is kept.
+func (s *service) ImportDocument(ctx context.Context, request *ImportDocumentRequest) ([]StatementOutcome, error) {
+	var outcomes []StatementOutcome
+	err := s.inTransaction(ctx, func(s *service) error {
+		outcomes = make([]StatementOutcome, 0, len(request.Document.Statements))
+		for i, statement := range request.Document.Statements {
+			outcome := StatementOutcome{Statement: i, ID: statement.ID}
+			for _, policy := range statement.Policies() {
+				policy.AccountID = request.AccountID
+				policy.TeamMemberID = request.TeamMemberID
+
+				imported, err := s.createImportedPolicy(ctx, &policy)
+				if err != nil {
+					return fmt.Errorf("statement %d: %w", i, err)
+				}
+				outcome.Policies = append(outcome.Policies, imported)
+			}
+			outcomes = append(outcomes, outcome)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// createImportedPolicy creates the policy within the import transaction, reporting validation errors
+// in the outcome. Any other error is returned.
+func (s *service) createImportedPolicy(ctx context.Context, policy *Policy) (ImportedPolicy, error) {
+	imported := ImportedPolicy{Resource: policy.Resource, Action: policy.Action}
+	var created *Policy
+	resource, err := s.validatePolicy(policy)
+	if err == nil {
+		created, err = s.createPolicy(ctx, policy, resource)
+	}
+	switch {
+	case err == nil:
+		imported.Status = ImportStatusCreated
+		imported.Resource = created.Resource
+		imported.PolicyID = created.ID
+	case errors.Is(err, ErrUserAlreadyHasBroaderPolicy):
+		imported.Status = ImportStatusRedundant
+	case isValidationError(err):
+		imported.Status = ImportStatusRejected
+		imported.Err = err
+	default:
+		return ImportedPolicy{}, err
+	}
+	return imported, nil
+}
+
+// isValidationError reports whether err comes from validatePolicy.
+func isValidationError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidEffect, ErrInvalidCondition, ErrInvalidPrincipal, ErrUnknownAction,
+		ErrInvalidResource, ErrUnknownResource, ErrInvalidValidity,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package policies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseDocument(t *testing.T) {
+	document, err := policies.ParseDocument([]byte(`{
+		"version": "1",
+		"statements": [
+			{"sid": "editors", "effect": "allow", "actions": ["read", "write"], "resources": ["blogs/*"]},
+			{"effect": "deny", "actions": ["write"], "resources": ["blogs/*/settings"], "condition": "ip in \"10.0.0.0/8\""}
+		]
+	}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &policies.Document{
+		Version: policies.DocumentVersion,
+		Statements: []policies.DocumentStatement{
+			{ID: "editors", Effect: policies.EffectAllow, Actions: []policies.Action{policies.ActionRead, policies.ActionWrite}, Resources: []string{"blogs/*"}},
+			{Effect: policies.EffectDeny, Actions: []policies.Action{policies.ActionWrite}, Resources: []string{"blogs/*/settings"}, Condition: `ip in "10.0.0.0/8"`},
+		},
+	}, document)
+
+	tests := []struct {
+		name      string
+		document  string
+		statement int
+	}{
+		{name: "malformed json", document: `{"version": "1",`, statement: -1},
+		{name: "unknown field", document: `{"version": "1", "statements": [{"effect": "allow", "actions": ["read"], "resource": ["blogs/*"]}]}`, statement: -1},
+		{name: "unsupported version", document: `{"version": "2", "statements": []}`, statement: -1},
+		{name: "invalid effect", document: `{"version": "1", "statements": [{"effect": "permit", "actions": ["read"], "resources": ["blogs/*"]}]}`, statement: 0},
+		{name: "no actions", document: `{"version": "1", "statements": [{"effect": "allow", "actions": ["read"], "resources": ["blogs/*"]}, {"effect": "allow", "resources": ["blogs/*"]}]}`, statement: 1},
+		{name: "no resources", document: `{"version": "1", "statements": [{"effect": "deny", "actions": ["read"], "resources": []}]}`, statement: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := policies.ParseDocument([]byte(tt.document))
+
+			var documentErr *policies.DocumentError
+			assert.ErrorIs(t, err, policies.ErrInvalidDocument)
+			assert.ErrorAs(t, err, &documentErr)
+			assert.Equal(t, tt.statement, documentErr.Statement)
+			assert.Nil(t, document)
+		})
+	}
+}
+
+func TestNewDocument(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC)
+
+	document := policies.NewDocument([]policies.Policy{
+		{ID: 1, Resource: "blogs/1/settings", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+		{ID: 2, Resource: "funnels/*", Action: policies.ActionWrite},
+		{ID: 3, Resource: "blogs/*", Action: policies.ActionRead},
+		{ID: 4, Resource: "funnels/*", Action: policies.ActionRead},
+		{ID: 5, Resource: "blogs/*", Action: policies.ActionWrite},
+		{ID: 6, Resource: "pages/*", Action: policies.ActionWrite, ExpiresAt: &expiresAt},
+	})
+
+	assert.Equal(t, &policies.Document{
+		Version: policies.DocumentVersion,
+		Statements: []policies.DocumentStatement{
+			{Effect: policies.EffectAllow, Actions: []policies.Action{policies.ActionRead, policies.ActionWrite}, Resources: []string{"blogs/*", "funnels/*"}},
+			{Effect: policies.EffectAllow, Actions: []policies.Action{policies.ActionWrite}, Resources: []string{"pages/*"}, ExpiresAt: &expiresAt},
+			{Effect: policies.EffectDeny, Actions: []policies.Action{policies.ActionWrite}, Resources: []string{"blogs/1/settings"}},
+		},
+	}, document)
+}
+
+func TestExportDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	test := setup(ctrl)
+	expired := time.Now().Add(-time.Hour)
+	test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+		Actions:      []policies.Action{policies.ActionRead, policies.ActionWrite},
+	}).Return([]policies.Policy{
+		{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionRead},
+		{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "funnels/*", Action: policies.ActionRead, ExpiresAt: &expired},
+	}, nil)
+	service := policies.NewService(test.mockRepository)
+
+	document, err := service.ExportDocument(t.Context(), &policies.ExportDocumentRequest{AccountID: 100, TeamMemberID: 200})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []policies.DocumentStatement{
+		{Effect: policies.EffectAllow, Actions: []policies.Action{policies.ActionRead}, Resources: []string{"blogs/*"}},
+	}, document.Statements)
+}
+
+func TestImportDocument(t *testing.T) {
+	t.Run("reports created, redundant, and rejected policies per statement", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite},
+		}, nil).Times(2)
+		test.mockRepository.EXPECT().Create(gomock.Any(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "funnels/*",
+			Action:       policies.ActionWrite,
+			Effect:       policies.EffectAllow,
+		}).DoAndReturn(func(_ any, policy *policies.Policy) (*policies.Policy, error) {
+			created := *policy
+			created.ID = 2
+			return &created, nil
+		})
+		service := policies.NewService(test.mockRepository)
+
+		outcomes, err := service.ImportDocument(t.Context(), &policies.ImportDocumentRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Document: &policies.Document{
+				Version: policies.DocumentVersion,
+				Statements: []policies.DocumentStatement{
+					{ID: "writers", Effect: policies.EffectAllow, Actions: []policies.Action{policies.ActionWrite}, Resources: []string{"blogs/1", "/funnels/*"}},
+					{Effect: policies.EffectAllow, Actions: []policies.Action{policies.ActionWrite}, Resources: []string{"blogs/a*"}},
+				},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, outcomes, 2)
+		assert.Equal(t, policies.StatementOutcome{
+			Statement: 0,
+			ID:        "writers",
+			Policies: []policies.ImportedPolicy{
+				{Resource: "blogs/1", Action: policies.ActionWrite, Status: policies.ImportStatusRedundant},
+				{Resource: "funnels/*", Action: policies.ActionWrite, Status: policies.ImportStatusCreated, PolicyID: 2},
+			},
+		}, outcomes[0])
+		assert.Equal(t, policies.ImportStatusRejected, outcomes[1].Policies[0].Status)
+		assert.ErrorIs(t, outcomes[1].Policies[0].Err, policies.ErrInvalidResource)
+	})
+
+	t.Run("rolls back every created policy on repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		repositoryErr := errors.New("connection refused")
+		var transactionErr error
+		test.mockRepository.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fn func(policies.Repository) error) error {
+				transactionErr = fn(test.mockRepository)
+				return transactionErr
+			})
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		// blogs/* is created within the transaction, then funnels/* fails and the transaction is rolled back.
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{ID: 1}, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repositoryErr)
+		service := policies.NewService(test.mockRepository)
+
+		outcomes, err := service.ImportDocument(t.Context(), &policies.ImportDocumentRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Document: &policies.Document{
+				Version: policies.DocumentVersion,
+				Statements: []policies.DocumentStatement{
+					{Effect: policies.EffectAllow, Actions: []policies.Action{policies.ActionRead}, Resources: []string{"blogs/*", "funnels/*"}},
+				},
+			},
+		})
+
+		assert.ErrorIs(t, err, repositoryErr)
+		assert.ErrorIs(t, transactionErr, repositoryErr)
+		assert.Nil(t, outcomes)
+	})
+}
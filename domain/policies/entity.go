@@ -1,18 +1,62 @@
 package policies
 
+import "time"
+
 type Action string
 
+// Read and write apply to every resource type. Other actions must be registered
+// per resource type, see ActionRegistry.
+const (
+	ActionRead           Action = "read"
+	ActionWrite          Action = "write"
+	ActionDelete         Action = "delete"
+	ActionPublish        Action = "publish"
+	ActionShare          Action = "share"
+	ActionManageSettings Action = "manage_settings"
+	ActionManage         Action = "manage"
+)
+
+type Effect string
+
 const (
-	ActionRead  Action = "read"
-	ActionWrite Action = "write"
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
 )
 
+// Policy targets exactly one principal: either a team member or a group.
 type Policy struct {
 	ID           int64
 	AccountID    int64
 	TeamMemberID int64
+	GroupID      int64
 	Resource     string
 	Action       Action
+	Effect       Effect     // Empty effect is treated as allow.
+	Condition    string     // Optional condition expression, see ParseCondition.
+	RoleID       int64      // Set when the grant is derived from a role instead of a direct policy.
+	NotBefore    *time.Time // Optional start of the validity window.
+	ExpiresAt    *time.Time // Optional end of the validity window, exclusive.
+}
+
+// IsDeny reports whether the policy explicitly denies access.
+func (p Policy) IsDeny() bool {
+	return p.Effect == EffectDeny
+}
+
+// IsTemporary reports whether the policy only applies within a validity window.
+func (p Policy) IsTemporary() bool {
+	return p.NotBefore != nil || p.ExpiresAt != nil
+}
+
+// IsActive reports whether the policy applies at the given time.
+func (p Policy) IsActive(at time.Time) bool {
+	if p.NotBefore != nil && at.Before(*p.NotBefore) {
+		return false
+	}
+	if p.ExpiresAt != nil && !at.Before(*p.ExpiresAt) {
+		return false
+	}
+	return true
 }
 
 type CheckPermissionRequest struct {
@@ -20,4 +64,100 @@ type CheckPermissionRequest struct {
 	TeamMemberID int64
 	Resource     string // E.g., blogs
 	Action       Action
+	Attributes   map[string]string // Request context evaluated by policy conditions. E.g., ip, time
+	AsOf         *time.Time        // Evaluates the policies as they were at the time, see Revision. Nil checks now.
+}
+
+// CheckPermissionsRequest checks many resources for one principal. E.g., every blog page in a list.
+type CheckPermissionsRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+	Items        []PermissionCheckItem
+	Attributes   map[string]string // Shared by every item.
+	AsOf         *time.Time        // See CheckPermissionRequest.
+}
+
+type PermissionCheckItem struct {
+	Resource string
+	Action   Action
+}
+
+// ListAccessibleResourcesRequest asks which resources under ResourcePrefix a member can perform Action on.
+// An empty prefix lists every resource.
+type ListAccessibleResourcesRequest struct {
+	AccountID      int64
+	TeamMemberID   int64
+	Action         Action
+	ResourcePrefix string // E.g., funnels
+}
+
+// AccessibleResources holds the patterns granting the action, and the deny patterns carving out of them.
+type AccessibleResources struct {
+	Allowed []AccessibleResource
+	Denied  []AccessibleResource
+}
+
+// AccessibleResource is an exact resource or a wildcard pattern. E.g., funnels/12, funnels/*
+type AccessibleResource struct {
+	Resource  string
+	Rule      MatchRule // Root, wildcard, or exact for policy resources, parent-read for their ancestors.
+	Condition string    // Set when the grant only applies while the condition holds.
+	PolicyIDs []int64
+	RoleIDs   []int64
+}
+
+// ListAuthorizedMembersRequest asks who can perform Action on Resource. E.g., blogs/12/settings, write
+type ListAuthorizedMembersRequest struct {
+	AccountID int64
+	Resource  string
+	Action    Action
+}
+
+// AuthorizedMember is a team member allowed on the resource, with the policies allowing it.
+type AuthorizedMember struct {
+	TeamMemberID int64
+	Decision     *Decision
+}
+
+type GetEffectivePermissionsRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+}
+
+// ActionPermissions holds the merged grants and denies of a member for one action.
+type ActionPermissions struct {
+	Action  Action
+	Allowed []EffectiveGrant
+	Denied  []EffectiveGrant
+}
+
+// EffectiveGrant is a resource pattern together with every policy granting it.
+type EffectiveGrant struct {
+	Resource  string
+	Condition string
+	NotBefore *time.Time
+	ExpiresAt *time.Time
+	Sources   []GrantSource
+}
+
+// GrantSource is where a grant comes from: a direct or group policy, or a role.
+// Action differs from the granted action when it comes through an implication. E.g., write grants read.
+type GrantSource struct {
+	PolicyID int64
+	GroupID  int64
+	RoleID   int64
+	Action   Action
+}
+
+// CreatePolicyPlan is what CreatePolicy would do with a policy.
+type CreatePolicyPlan struct {
+	Policy *Policy // Validated and normalized. E.g., /blogs/* becomes blogs/*
+	// IsRedundant is set when BroaderPolicyID already covers the policy, see ErrUserAlreadyHasBroaderPolicy.
+	IsRedundant     bool
+	BroaderPolicyID int64
+	// DeletedPolicyIDs are the narrower policies the new policy replaces.
+	DeletedPolicyIDs []int64
+	// ResultingPolicies are the principal's policies on the action and the actions implying it,
+	// after the change. The new policy has no ID yet.
+	ResultingPolicies []Policy
 }
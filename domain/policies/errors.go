@@ -4,4 +4,19 @@ import "errors"
 
 var (
 	ErrUserAlreadyHasBroaderPolicy = errors.New("user already has broader policy; no need to add")
+	ErrInvalidEffect               = errors.New("invalid policy effect; must be allow or deny")
+	ErrInvalidCondition            = errors.New("invalid policy condition")
+	ErrUnevaluableCondition        = errors.New("policy condition cannot be evaluated")
+	ErrInvalidPrincipal            = errors.New("policy must target exactly one of team member or group")
+	ErrUnknownAction               = errors.New("action is not registered for the resource type")
+	ErrInvalidResource             = errors.New("invalid resource pattern")
+	ErrUnknownResource             = errors.New("resource matches no registered resource template")
+	ErrInvalidValidity             = errors.New("policy must not expire before it becomes active")
+	ErrPermissionCheckUnavailable  = errors.New("permission check unavailable")
+	ErrPermissionDenied            = errors.New("permission denied")
+	ErrInvalidDocument             = errors.New("invalid policy document")
+	ErrInvalidRule                 = errors.New("invalid policy rule")
+	ErrRevisionNotFound            = errors.New("policy revision not found")
+	ErrHistoryUnavailable          = errors.New("policy history is not recorded that far back")
+	ErrHistoryIncomplete           = errors.New("decision depends on roles or group memberships, which have no history")
 )
@@ -0,0 +1,216 @@
+package policies
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// policyTrie holds the policies loaded for one action, compiled for repeated checks.
+// Patterns are stored segment by segment, so a check walks the requested path once
+// instead of matching every policy.
+type policyTrie struct {
+	action    Action
+	policies  []Policy
+	resources []ResourcePath
+	roots     []int // Root policies, such as *, match every resource.
+	root      *trieNode
+}
+
+type trieNode struct {
+	children  map[string]*trieNode
+	wildcard  *trieNode // Matches exactly one segment.
+	recursive *trieNode // Matches zero or more segments, see isRecursive.
+	// isRecursive is set on the node following **: it loops on any segment, so ** can absorb more of the path.
+	isRecursive bool
+	terminal    []int // Policies whose pattern ends here.
+	// descendants are the allow policies whose pattern continues below here. Reading the
+	// path leading to this node is allowed through them, see MatchRuleParentRead.
+	descendants []int
+}
+
+// policyMatch is a policy matching the requested resource, before its validity and condition are checked.
+type policyMatch struct {
+	index int
+	rule  MatchRule
+}
+
+// compilePolicies builds the trie for checks on action. Policies must be loaded for action,
+// see ActionRegistry.ImplyingActions; denies on other actions are dropped since they never apply.
+func compilePolicies(ctx context.Context, policies []Policy, action Action) *policyTrie {
+	trie := &policyTrie{
+		action:    action,
+		policies:  policies,
+		resources: make([]ResourcePath, len(policies)),
+		root:      &trieNode{},
+	}
+
+	for i, policy := range policies {
+		if policy.IsDeny() && policy.Action != action {
+			continue
+		}
+
+		// Stored resources may predate normalization. E.g., /blogs/* in the seed data.
+		resource, err := ParseResourcePath(policy.Resource)
+		if err != nil {
+			slog.WarnContext(ctx, "invalid policy resource", "policy_id", policy.ID, "resource", policy.Resource)
+			continue
+		}
+		trie.resources[i] = resource
+
+		if resource.IsRoot() {
+			trie.roots = append(trie.roots, i)
+			continue
+		}
+		trie.insert(i, resource)
+	}
+
+	return trie
+}
+
+// insert stores the pattern with a trailing * expanded, like Covers, so blogs/* is stored as blogs/*/**.
+func (t *policyTrie) insert(index int, resource ResourcePath) {
+	node := t.root
+	for _, segment := range resource.matchSegments() {
+		if !t.policies[index].IsDeny() {
+			node.descendants = append(node.descendants, index)
+		}
+
+		switch segment {
+		case wildcardSegment:
+			if node.wildcard == nil {
+				node.wildcard = &trieNode{}
+			}
+			node = node.wildcard
+		case recursiveWildcardSegment:
+			if node.recursive == nil {
+				node.recursive = &trieNode{isRecursive: true}
+			}
+			node = node.recursive
+		default:
+			if node.children == nil {
+				node.children = make(map[string]*trieNode)
+			}
+			if node.children[segment] == nil {
+				node.children[segment] = &trieNode{}
+			}
+			node = node.children[segment]
+		}
+	}
+
+	node.terminal = append(node.terminal, index)
+}
+
+// match returns the policies matching the requested resource, in policy order,
+// with the same rules as checkResourceAccess for allows and exact, wildcard, or root matches for denies.
+func (t *policyTrie) match(requestResource ResourcePath) []policyMatch {
+	rules := make(map[int]MatchRule)
+	for _, index := range t.roots {
+		rules[index] = MatchRuleRoot
+	}
+
+	for _, index := range t.coveringPolicies(requestResource) {
+		if requestResource.Equal(t.resources[index]) {
+			rules[index] = MatchRuleExact
+		} else {
+			rules[index] = MatchRuleWildcard
+		}
+	}
+
+	// Only read is granted on ancestors. A deny on a child never denies reading its parent,
+	// so denies are never stored as descendants.
+	if t.action == ActionRead {
+		for _, index := range t.descendantPolicies(requestResource) {
+			if _, ok := rules[index]; !ok {
+				rules[index] = MatchRuleParentRead
+			}
+		}
+	}
+
+	matches := make([]policyMatch, 0, len(rules))
+	for index, rule := range rules {
+		matches = append(matches, policyMatch{index: index, rule: rule})
+	}
+	slices.SortFunc(matches, func(a, b policyMatch) int {
+		return a.index - b.index
+	})
+	return matches
+}
+
+// coveringPolicies walks every pattern covering the requested resource at once, see ResourcePath.Covers.
+func (t *policyTrie) coveringPolicies(requestResource ResourcePath) []int {
+	states := withRecursive(nil, t.root)
+	for _, segment := range requestResource.matchSegments() {
+		var next []*trieNode
+		for _, node := range states {
+			if node.isRecursive {
+				next = withRecursive(next, node)
+			}
+			// Only ** covers **.
+			if segment == recursiveWildcardSegment {
+				continue
+			}
+			if node.wildcard != nil {
+				next = withRecursive(next, node.wildcard)
+			}
+			if child := node.children[segment]; child != nil {
+				next = withRecursive(next, child)
+			}
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+		states = next
+	}
+
+	var indexes []int
+	for _, node := range states {
+		indexes = append(indexes, node.terminal...)
+	}
+	return indexes
+}
+
+// descendantPolicies walks the allow patterns matching some resource strictly under the requested one,
+// see ResourcePath.CoversDescendantOf.
+func (t *policyTrie) descendantPolicies(requestResource ResourcePath) []int {
+	var indexes []int
+	states := []*trieNode{t.root}
+	for _, segment := range requestResource.segments {
+		var next []*trieNode
+		for _, node := range states {
+			// Everything through ** goes under the requested resource, whatever follows.
+			if node.recursive != nil {
+				indexes = append(indexes, node.recursive.terminal...)
+				indexes = append(indexes, node.recursive.descendants...)
+			}
+			if node.wildcard != nil {
+				next = append(next, node.wildcard)
+			}
+			if child := node.children[segment]; child != nil {
+				next = append(next, child)
+			}
+		}
+		states = next
+	}
+
+	for _, node := range states {
+		indexes = append(indexes, node.descendants...)
+	}
+
+	// Deny policies are terminal on recursive nodes too; they never grant parent-read.
+	return slices.DeleteFunc(indexes, func(index int) bool {
+		return t.policies[index].IsDeny()
+	})
+}
+
+// withRecursive adds the node, and the ** nodes directly following it since ** also matches nothing.
+func withRecursive(nodes []*trieNode, node *trieNode) []*trieNode {
+	for ; node != nil; node = node.recursive {
+		if slices.Contains(nodes, node) {
+			return nodes
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
@@ -0,0 +1,213 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// linearEvaluate is the evaluator the trie replaced: every policy is matched against the resource.
+// It is kept as the reference the trie must agree with.
+func (s *service) linearEvaluate(ctx context.Context, policies []Policy, requestResource ResourcePath, action Action, attributes map[string]string) *Decision {
+	now := s.now()
+
+	decision := deny(DenialReasonNoMatchingPolicy)
+	for _, policy := range policies {
+		policyResource, err := ParseResourcePath(policy.Resource)
+		if err != nil {
+			continue
+		}
+
+		var rule MatchRule
+		var isMatched bool
+		if policy.IsDeny() {
+			if policy.Action != action {
+				continue
+			}
+			rule, isMatched = linearCheckResourceDenied(s, policyResource, requestResource)
+		} else {
+			rule, isMatched = s.checkResourceAccess(policyResource, requestResource, action)
+		}
+		if !isMatched {
+			continue
+		}
+
+		if !policy.IsActive(now) {
+			if !decision.Allowed && !policy.IsDeny() && decision.Reason == DenialReasonNoMatchingPolicy {
+				decision.Reason = DenialReasonPolicyInactive
+			}
+			continue
+		}
+
+		if !s.checkCondition(ctx, policy, attributes) {
+			if !decision.Allowed && !policy.IsDeny() {
+				decision.Reason = DenialReasonConditionNotMet
+			}
+			continue
+		}
+
+		if policy.IsDeny() {
+			explicitDeny := deny(DenialReasonExplicitDeny)
+			explicitDeny.addMatch(policy, rule)
+			return explicitDeny
+		}
+
+		decision.Allowed = true
+		decision.Reason = ""
+		decision.addMatch(policy, rule)
+	}
+
+	return decision
+}
+
+// linearCheckResourceDenied matches deny policies: unlike allows, a deny on a child never denies reading its parent.
+func linearCheckResourceDenied(s *service, policyResource, requestResource ResourcePath) (MatchRule, bool) {
+	if policyResource.IsRoot() {
+		return MatchRuleRoot, true
+	}
+
+	if policyResource.Equal(requestResource) {
+		return MatchRuleExact, true
+	}
+
+	return MatchRuleWildcard, s.checkBroaderPolicy(policyResource, requestResource)
+}
+
+func newEquivalenceService() *service {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &service{actions: NewActionRegistry(), now: func() time.Time { return now }}
+}
+
+func assertEquivalent(t *testing.T, s *service, policies []Policy, resources []string) {
+	t.Helper()
+
+	attributes := map[string]string{AttributeTime: "10:00", AttributeIP: "10.0.0.1"}
+	for _, action := range []Action{ActionRead, ActionWrite} {
+		trie := compilePolicies(t.Context(), policies, action)
+		for _, resource := range resources {
+			requestResource, err := ParseResourcePath(resource)
+			if err != nil {
+				continue
+			}
+
+			want := s.linearEvaluate(t.Context(), policies, requestResource, action, attributes)
+			got := s.evaluate(t.Context(), trie, requestResource, attributes)
+			if !assert.Equal(t, want, got, "%s %s", action, resource) {
+				return
+			}
+		}
+	}
+}
+
+func TestTrieEvaluatorEquivalence(t *testing.T) {
+	past := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	policies := []Policy{
+		{ID: 1, Resource: "blogs/*", Action: ActionRead},
+		{ID: 2, Resource: "blogs/11/pages/12", Action: ActionWrite},
+		{ID: 3, Resource: "blogs/*/settings", Action: ActionWrite, Effect: EffectDeny},
+		{ID: 4, Resource: "funnels/*/pages/**", Action: ActionWrite},
+		{ID: 5, Resource: "funnels/1/pages/2", Action: ActionWrite, Effect: EffectDeny, Condition: `ip in "10.0.0.0/8"`},
+		{ID: 6, Resource: "articles", Action: ActionRead},
+		{ID: 7, Resource: "articles/**", Action: ActionWrite, ExpiresAt: &past},
+		{ID: 8, Resource: "articles/1", Action: ActionWrite, NotBefore: &future},
+		{ID: 9, Resource: "pages/**/components/*", Action: ActionWrite, Condition: "time >= 18:00"},
+		{ID: 10, Resource: "/blogs//12/", Action: ActionWrite},
+		{ID: 11, Resource: "blogs/a*", Action: ActionWrite},
+		{ID: 12, RoleID: 3, Resource: "reports/*", Action: ActionRead},
+		{ID: 13, Resource: "blogs/12/settings", Action: ActionRead, Effect: EffectDeny},
+		{ID: 14, Resource: "**", Action: ActionRead, Effect: EffectDeny, Condition: "time > 20:00"},
+	}
+	resources := []string{
+		"blogs", "blogs/1", "blogs/11", "blogs/110", "blogs/11/pages", "blogs/11/pages/12", "blogs/12",
+		"blogs/12/settings", "blogs/1/settings/x", "funnels", "funnels/1", "funnels/1/pages", "funnels/1/pages/2",
+		"funnels/1/pages/2/components/3", "articles", "articles/1", "articles/1/2", "pages", "pages/components/1",
+		"pages/1/2/components/3", "pages/1/components", "reports", "reports/1", "blogs/*", "blogs/**", "funnels/*/pages/*",
+		"*", "**",
+	}
+
+	assertEquivalent(t, newEquivalenceService(), policies, resources)
+
+	t.Run("with root policies", func(t *testing.T) {
+		withRoot := append([]Policy{{ID: 20, Resource: "*", Action: ActionRead}}, policies...)
+		withRoot = append(withRoot, Policy{ID: 21, Resource: "*", Action: ActionWrite, Effect: EffectDeny, Condition: "time < 09:00"})
+		assertEquivalent(t, newEquivalenceService(), withRoot, resources)
+	})
+}
+
+func TestTrieEvaluatorEquivalenceRandomized(t *testing.T) {
+	segments := []string{"blogs", "pages", "1", "2", "*", "**"}
+	randomPath := func(r *rand.Rand) string {
+		parts := make([]string, 1+r.IntN(4))
+		for i := range parts {
+			parts[i] = segments[r.IntN(len(segments))]
+		}
+		return strings.Join(parts, "/")
+	}
+
+	for seed := range uint64(200) {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			r := rand.New(rand.NewPCG(seed, seed))
+
+			policies := make([]Policy, 1+r.IntN(12))
+			for i := range policies {
+				policies[i] = Policy{ID: int64(i + 1), Resource: randomPath(r), Action: []Action{ActionRead, ActionWrite}[r.IntN(2)]}
+				if r.IntN(4) == 0 {
+					policies[i].Effect = EffectDeny
+				}
+				if r.IntN(5) == 0 {
+					policies[i].Condition = "time >= 12:00"
+				}
+			}
+
+			resources := make([]string, 20)
+			for i := range resources {
+				resources[i] = randomPath(r)
+			}
+
+			assertEquivalent(t, newEquivalenceService(), policies, resources)
+		})
+	}
+}
+
+// BenchmarkEvaluate compares a member with n granular grants checked linearly, through a trie compiled
+// for the single check, as CheckPermissions does, and through an already compiled trie, as every further
+// resource of a batch check is.
+func BenchmarkEvaluate(b *testing.B) {
+	s := newEquivalenceService()
+	attributes := map[string]string{AttributeTime: "10:00"}
+	requestResource, _ := ParseResourcePath("blogs/7/pages/3")
+
+	for _, n := range []int{10, 100, 1000} {
+		policies := make([]Policy, n)
+		for i := range policies {
+			policies[i] = Policy{ID: int64(i + 1), Resource: fmt.Sprintf("blogs/%d/pages/%d", i/10, i%10), Action: ActionWrite}
+		}
+
+		b.Run(fmt.Sprintf("linear/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				s.linearEvaluate(b.Context(), policies, requestResource, ActionWrite, attributes)
+			}
+		})
+
+		b.Run(fmt.Sprintf("compile and match/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				trie := compilePolicies(b.Context(), policies, ActionWrite)
+				s.evaluate(b.Context(), trie, requestResource, attributes)
+			}
+		})
+
+		b.Run(fmt.Sprintf("match/%d", n), func(b *testing.B) {
+			trie := compilePolicies(b.Context(), policies, ActionWrite)
+			for b.Loop() {
+				s.evaluate(b.Context(), trie, requestResource, attributes)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// checkPermissionsAsOf evaluates the checks against the policies that existed at request.AsOf,
+// reconstructed from the revisions recorded since. Validity windows and the time attribute are
+// evaluated at AsOf too.
+//
+// Roles and group memberships have no history, so a decision a role or group grant contributed to
+// is refused with ErrHistoryIncomplete rather than answered with today's roles and groups.
+// A membership that ended since AsOf contributes nothing today, so it cannot be detected.
+func (s *service) checkPermissionsAsOf(ctx context.Context, request *CheckPermissionsRequest) ([]*Decision, error) {
+	asOf := *request.AsOf
+
+	// Revisions only tell what changed since they were first recorded, not what existed before.
+	start, err := s.repo.GetRevisionsStart(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get revisions start: %w", ErrPermissionCheckUnavailable, err)
+	}
+	if start.IsZero() || asOf.Before(start) {
+		return nil, ErrHistoryUnavailable
+	}
+
+	// Evaluate with the exact same service, only the storage and the clock differ.
+	repo := &pointInTimeRepository{Repository: s.repo, at: asOf, groupPolicyIDs: make(map[int64]bool)}
+	historical := *s
+	historical.repo = repo
+	historical.now = func() time.Time { return asOf }
+
+	current := *request
+	current.AsOf = nil
+	decisions, err := historical.CheckPermissions(ctx, &current)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decision := range decisions {
+		if len(decision.RoleIDs) > 0 || slices.ContainsFunc(decision.PolicyIDs, func(policyID int64) bool {
+			return repo.groupPolicyIDs[policyID]
+		}) {
+			return nil, ErrHistoryIncomplete
+		}
+	}
+	return decisions, nil
+}
+
+// pointInTimeRepository returns policies as they were at a given time, by undoing every revision
+// of the principal recorded after it on the current policies.
+type pointInTimeRepository struct {
+	Repository
+	at time.Time
+
+	// Revisions are loaded once per principal, however many actions are checked.
+	principal *GetRevisionsRequest
+	revisions []Revision
+
+	groupPolicyIDs map[int64]bool // Policies returned for a group, whose membership is read as of now.
+}
+
+func (r *pointInTimeRepository) Get(ctx context.Context, request *GetPolicyRequest) ([]Policy, error) {
+	policies, err := r.Repository.Get(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := r.getRevisions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revision := range slices.Backward(revisions) {
+		policies = slices.DeleteFunc(policies, func(policy Policy) bool {
+			return policy.ID == revision.PolicyID
+		})
+		if revision.Before != nil && request.matches(*revision.Before) {
+			policies = append(policies, *revision.Before)
+		}
+	}
+
+	for _, policy := range policies {
+		if policy.GroupID != 0 {
+			r.groupPolicyIDs[policy.ID] = true
+		}
+	}
+
+	return policies, nil
+}
+
+func (r *pointInTimeRepository) getRevisions(ctx context.Context, request *GetPolicyRequest) ([]Revision, error) {
+	principal := &GetRevisionsRequest{
+		AccountID:    request.AccountID,
+		TeamMemberID: request.TeamMemberID,
+		GroupIDs:     request.GroupIDs,
+		After:        r.at,
+	}
+	if r.principal != nil && r.principal.AccountID == principal.AccountID &&
+		r.principal.TeamMemberID == principal.TeamMemberID && slices.Equal(r.principal.GroupIDs, principal.GroupIDs) {
+		return r.revisions, nil
+	}
+
+	revisions, err := r.Repository.GetRevisions(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	r.principal, r.revisions = principal, revisions
+	return revisions, nil
+}
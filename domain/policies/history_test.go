@@ -0,0 +1,259 @@
+package policies_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckPermissionAsOf(t *testing.T) {
+	historyStart := time.Now().Add(-72 * time.Hour)
+	asOf := time.Now().Add(-48 * time.Hour)
+	expiredSince := time.Now().Add(-24 * time.Hour)
+
+	deleted := policies.Policy{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow}
+	temporary := policies.Policy{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow, ExpiresAt: &expiredSince}
+	deny := policies.Policy{ID: 3, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1", Action: policies.ActionRead, Effect: policies.EffectDeny}
+	otherMember := policies.Policy{ID: 4, AccountID: 100, TeamMemberID: 300, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow}
+
+	tests := []struct {
+		name          string
+		mockPolicies  []policies.Policy
+		mockRevisions []policies.Revision
+		wantPermitted bool
+	}{
+		{
+			name: "permission granted by a policy deleted since",
+			mockRevisions: []policies.Revision{
+				{ID: 10, PolicyID: 1, Operation: policies.RevisionOperationDeleted, Before: &deleted},
+			},
+			wantPermitted: true,
+		},
+		{
+			name:         "permission denied by a deny created since is ignored",
+			mockPolicies: []policies.Policy{deleted, deny},
+			mockRevisions: []policies.Revision{
+				{ID: 10, PolicyID: 3, Operation: policies.RevisionOperationCreated, After: &deny},
+			},
+			wantPermitted: true,
+		},
+		{
+			name:         "permission granted by a policy created since is ignored",
+			mockPolicies: []policies.Policy{deleted},
+			mockRevisions: []policies.Revision{
+				{ID: 10, PolicyID: 1, Operation: policies.RevisionOperationCreated, After: &deleted},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "permission granted by a policy expired since",
+			mockRevisions: []policies.Revision{
+				{ID: 10, PolicyID: 2, Operation: policies.RevisionOperationDeleted, Actor: policies.ActorSweeper, Before: &temporary},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "policy of another member deleted since is ignored",
+			mockRevisions: []policies.Revision{
+				{ID: 10, PolicyID: 4, Operation: policies.RevisionOperationDeleted, Before: &otherMember},
+			},
+			wantPermitted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			test := setup(ctrl)
+			test.mockRepository.EXPECT().GetRevisionsStart(gomock.Any()).Return(historyStart, nil)
+			test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(tt.mockPolicies, nil)
+			test.mockRepository.EXPECT().GetRevisions(gomock.Any(), &policies.GetRevisionsRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				After:        asOf,
+			}).Return(tt.mockRevisions, nil)
+			service := policies.NewService(test.mockRepository)
+
+			decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionRead,
+				AsOf:         &asOf,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPermitted, decision.Allowed)
+		})
+	}
+}
+
+func TestCheckPermissionAsOfBeforeHistory(t *testing.T) {
+	asOf := time.Now().Add(-48 * time.Hour)
+
+	tests := []struct {
+		name         string
+		historyStart time.Time
+	}{
+		{
+			name:         "no revision recorded yet",
+			historyStart: time.Time{},
+		},
+		{
+			name:         "revisions recorded after the time",
+			historyStart: time.Now().Add(-24 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			test := setup(ctrl)
+			test.mockRepository.EXPECT().GetRevisionsStart(gomock.Any()).Return(tt.historyStart, nil)
+			service := policies.NewService(test.mockRepository)
+
+			_, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionRead,
+				AsOf:         &asOf,
+			})
+
+			assert.ErrorIs(t, err, policies.ErrHistoryUnavailable)
+		})
+	}
+}
+
+func TestCheckPermissionAsOfIncompleteHistory(t *testing.T) {
+	asOf := time.Now().Add(-48 * time.Hour)
+
+	t.Run("refuses a decision a group policy contributed to", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().GetRevisionsStart(gomock.Any()).Return(asOf.Add(-time.Hour), nil)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, GroupID: 1, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow},
+		}, nil)
+		test.mockRepository.EXPECT().GetRevisions(gomock.Any(), gomock.Any()).Return(nil, nil)
+		service := policies.NewService(test.mockRepository, policies.WithGroupResolver(stubGroupResolver{1}))
+
+		_, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+			AsOf:         &asOf,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrHistoryIncomplete)
+	})
+
+	t.Run("refuses a decision a role grant contributed to", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().GetRevisionsStart(gomock.Any()).Return(asOf.Add(-time.Hour), nil)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+		test.mockRepository.EXPECT().GetRevisions(gomock.Any(), gomock.Any()).Return(nil, nil)
+		service := policies.NewService(test.mockRepository, policies.WithGrantProvider(stubGrantProvider{
+			{RoleID: 5, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow},
+		}))
+
+		_, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+			AsOf:         &asOf,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrHistoryIncomplete)
+	})
+
+	t.Run("answers when only direct policies decide", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().GetRevisionsStart(gomock.Any()).Return(asOf.Add(-time.Hour), nil)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, GroupID: 1, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow},
+			{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1", Action: policies.ActionRead, Effect: policies.EffectDeny},
+		}, nil)
+		test.mockRepository.EXPECT().GetRevisions(gomock.Any(), gomock.Any()).Return(nil, nil)
+		service := policies.NewService(test.mockRepository, policies.WithGroupResolver(stubGroupResolver{1}))
+
+		decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+			AsOf:         &asOf,
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, []int64{2}, decision.PolicyIDs)
+	})
+
+	t.Run("wraps a failure to read the history start", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().GetRevisionsStart(gomock.Any()).Return(time.Time{}, errors.New("connection refused"))
+		service := policies.NewService(test.mockRepository)
+
+		_, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+			AsOf:         &asOf,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrPermissionCheckUnavailable)
+	})
+}
+
+func TestCheckPermissionsAsOf(t *testing.T) {
+	t.Run("loads the revisions of the principal once for every action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		asOf := time.Now().Add(-48 * time.Hour)
+		deleted := policies.Policy{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite, Effect: policies.EffectAllow}
+		test.mockRepository.EXPECT().GetRevisionsStart(gomock.Any()).Return(asOf.Add(-time.Hour), nil)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		test.mockRepository.EXPECT().GetRevisions(gomock.Any(), &policies.GetRevisionsRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			After:        asOf,
+		}).Return([]policies.Revision{
+			{ID: 10, PolicyID: 1, Operation: policies.RevisionOperationDeleted, Before: &deleted},
+		}, nil).Times(1)
+		service := policies.NewService(test.mockRepository)
+
+		decisions, err := service.CheckPermissions(t.Context(), &policies.CheckPermissionsRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Items: []policies.PermissionCheckItem{
+				{Resource: "blogs/1", Action: policies.ActionRead},
+				{Resource: "blogs/1", Action: policies.ActionWrite},
+				{Resource: "blogs/1", Action: policies.ActionDelete},
+			},
+			AsOf: &asOf,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, decisions, 3)
+		assert.True(t, decisions[1].Allowed)
+		assert.False(t, decisions[2].Allowed)
+	})
+}
@@ -0,0 +1,228 @@
+package policies
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+type LintKind string
+
+const (
+	// LintKindRedundant is a policy a broader policy of the same principal already covers. Fixed by deleting it.
+	LintKindRedundant LintKind = "redundant_policy"
+	// LintKindUnnormalized is a resource stored in a non-canonical form. E.g., /blogs/*. Fixed by normalizing it.
+	LintKindUnnormalized LintKind = "unnormalized_resource"
+	// LintKindUnregistered is a policy on a resource or action the registries do not know.
+	LintKindUnregistered LintKind = "unregistered_resource"
+	// LintKindInvalid is a policy CreatePolicy would reject for any other reason. E.g., a malformed condition.
+	LintKindInvalid LintKind = "invalid_policy"
+	// LintKindConflict is an allow a deny of the same principal entirely covers, so it may never grant anything.
+	LintKindConflict LintKind = "conflicting_policies"
+	// LintKindRootGrant is an allow on every resource. E.g., *
+	LintKindRootGrant LintKind = "root_grant"
+)
+
+type LintPoliciesRequest struct {
+	AccountID int64
+	// Fix deletes redundant policies and normalizes resources. Other findings need a human decision.
+	Fix bool
+}
+
+// LintFinding is a problem found on PolicyID. RelatedPolicyID is the broader policy of a redundant
+// one, or the deny of a conflict.
+type LintFinding struct {
+	Kind            LintKind
+	PolicyID        int64
+	RelatedPolicyID int64
+	Message         string
+	IsFixable       bool
+	IsFixed         bool
+}
+
+// LintPolicies reports findings ordered by policy ID. Redundancy and conflicts are only looked for
+// between policies of the same principal, like CreatePolicy does.
+func (s *service) LintPolicies(ctx context.Context, request *LintPoliciesRequest) ([]LintFinding, error) {
+	policies, err := s.repo.GetByAccount(ctx, request.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(policies, func(a, b Policy) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	resources := make([]*ResourcePath, len(policies))
+	var findings []LintFinding
+	for i, policy := range policies {
+		normalized := policy
+		switch _, err := s.validatePolicy(&normalized); {
+		case errors.Is(err, ErrUnknownResource) || errors.Is(err, ErrUnknownAction):
+			findings = append(findings, LintFinding{
+				Kind:     LintKindUnregistered,
+				PolicyID: policy.ID,
+				Message:  fmt.Sprintf("%s on %s: %s", policy.Action, policy.Resource, err),
+			})
+		case err != nil:
+			findings = append(findings, LintFinding{
+				Kind:     LintKindInvalid,
+				PolicyID: policy.ID,
+				Message:  err.Error(),
+			})
+		}
+
+		// Registry findings do not keep the policy from matching, so it still takes part in the analysis below.
+		resource, err := ParseResourcePath(policy.Resource)
+		if err != nil {
+			continue
+		}
+		resources[i] = &resource
+
+		if resources[i].String() != policy.Resource {
+			findings = append(findings, LintFinding{
+				Kind:      LintKindUnnormalized,
+				PolicyID:  policy.ID,
+				Message:   fmt.Sprintf("resource %s should be stored as %s", policy.Resource, resources[i]),
+				IsFixable: true,
+			})
+		}
+
+		if !policy.IsDeny() && resources[i].IsRoot() {
+			findings = append(findings, LintFinding{
+				Kind:     LintKindRootGrant,
+				PolicyID: policy.ID,
+				Message:  fmt.Sprintf("%s is granted on every resource", policy.Action),
+			})
+		}
+	}
+
+	for i, policy := range policies {
+		if resources[i] == nil {
+			continue
+		}
+
+		if broader, ok := s.findShadowingPolicy(i, policies, resources); ok {
+			findings = append(findings, LintFinding{
+				Kind:            LintKindRedundant,
+				PolicyID:        policy.ID,
+				RelatedPolicyID: broader.ID,
+				Message:         fmt.Sprintf("%s on %s is covered by policy %d, %s on %s", policy.Action, policy.Resource, broader.ID, broader.Action, broader.Resource),
+				IsFixable:       true,
+			})
+		}
+
+		if denial, ok := s.findConflictingDeny(i, policies, resources); ok {
+			findings = append(findings, LintFinding{
+				Kind:            LintKindConflict,
+				PolicyID:        policy.ID,
+				RelatedPolicyID: denial.ID,
+				Message:         fmt.Sprintf("allow %s on %s is denied by policy %d on %s", policy.Action, policy.Resource, denial.ID, denial.Resource),
+			})
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b LintFinding) int {
+		return cmp.Compare(a.PolicyID, b.PolicyID)
+	})
+
+	if request.Fix {
+		// Fixes apply together, so a failure never leaves a half-fixed policy set.
+		err := s.inTransaction(ctx, func(s *service) error {
+			return s.fixFindings(ctx, findings, policies, resources)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return findings, nil
+}
+
+// findShadowingPolicy applies the findBroaderPolicy rules to a stored policy. When two policies cover
+// each other, such as duplicates, the one with the lowest ID is kept.
+func (s *service) findShadowingPolicy(i int, policies []Policy, resources []*ResourcePath) (Policy, bool) {
+	policy := policies[i]
+	for j, broader := range policies {
+		if i == j || resources[j] == nil || !isSamePrincipal(policy, broader) || !s.isShadowing(broader, policy) {
+			continue
+		}
+		if !s.coversGrant(*resources[j], *resources[i]) {
+			continue
+		}
+		if j > i && s.isShadowing(policy, broader) && s.coversGrant(*resources[i], *resources[j]) {
+			continue
+		}
+
+		return broader, true
+	}
+	return Policy{}, false
+}
+
+// isShadowing reports whether broader could make policy redundant, resources aside.
+func (s *service) isShadowing(broader, policy Policy) bool {
+	if broader.IsDeny() != policy.IsDeny() || broader.Condition != "" || broader.IsTemporary() {
+		return false
+	}
+	if broader.IsDeny() {
+		return broader.Action == policy.Action
+	}
+	return s.actions.Implies(broader.Action, policy.Action)
+}
+
+// findConflictingDeny finds a deny covering the whole resource of an allow, on an action the allow grants.
+func (s *service) findConflictingDeny(i int, policies []Policy, resources []*ResourcePath) (Policy, bool) {
+	policy := policies[i]
+	if policy.IsDeny() {
+		return Policy{}, false
+	}
+
+	for j, denial := range policies {
+		if resources[j] == nil || !denial.IsDeny() || !isSamePrincipal(policy, denial) {
+			continue
+		}
+		if denial.Action == policy.Action && s.coversGrant(*resources[j], *resources[i]) {
+			return denial, true
+		}
+	}
+	return Policy{}, false
+}
+
+// fixFindings deletes redundant policies first, so a policy is never normalized only to be deleted.
+func (s *service) fixFindings(ctx context.Context, findings []LintFinding, policies []Policy, resources []*ResourcePath) error {
+	var deletedIDs []int64
+	for i, finding := range findings {
+		if finding.Kind != LintKindRedundant {
+			continue
+		}
+		if err := s.repo.Delete(ctx, strconv.FormatInt(finding.PolicyID, 10)); err != nil {
+			return fmt.Errorf("failed to delete redundant policy %d: %w", finding.PolicyID, err)
+		}
+		deletedIDs = append(deletedIDs, finding.PolicyID)
+		findings[i].IsFixed = true
+	}
+
+	for i, finding := range findings {
+		if finding.Kind != LintKindUnnormalized {
+			continue
+		}
+		// Deleting the policy resolved the finding too.
+		if slices.Contains(deletedIDs, finding.PolicyID) {
+			findings[i].IsFixed = true
+			continue
+		}
+
+		j := slices.IndexFunc(policies, func(policy Policy) bool { return policy.ID == finding.PolicyID })
+		if err := s.repo.UpdateResource(ctx, finding.PolicyID, resources[j].String()); err != nil {
+			return fmt.Errorf("failed to normalize policy %d: %w", finding.PolicyID, err)
+		}
+		findings[i].IsFixed = true
+	}
+
+	return nil
+}
+
+func isSamePrincipal(a, b Policy) bool {
+	return a.TeamMemberID == b.TeamMemberID && a.GroupID == b.GroupID
+}
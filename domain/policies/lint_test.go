@@ -0,0 +1,114 @@
+package policies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLintPolicies(t *testing.T) {
+	accountPolicies := []policies.Policy{
+		{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "/blogs/*", Action: policies.ActionWrite, Effect: policies.EffectAllow},
+		{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "blogs/12/pages", Action: policies.ActionRead, Effect: policies.EffectAllow},
+		{ID: 3, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite, Effect: policies.EffectAllow},
+		{ID: 4, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*/settings", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+		{ID: 5, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/settings", Action: policies.ActionWrite, Effect: policies.EffectAllow},
+		{ID: 6, AccountID: 100, TeamMemberID: 300, Resource: "blogs/1/settings", Action: policies.ActionWrite, Effect: policies.EffectAllow},
+		{ID: 7, AccountID: 100, GroupID: 10, Resource: "*", Action: policies.ActionRead, Effect: policies.EffectAllow},
+		{ID: 8, AccountID: 100, GroupID: 10, Resource: "reports/*", Action: policies.ActionRead, Effect: policies.EffectAllow},
+		{ID: 9, AccountID: 100, TeamMemberID: 300, Resource: "blogs/1/*", Action: policies.ActionRead, Condition: "time <"},
+	}
+
+	wantFindings := []policies.LintFinding{
+		{Kind: policies.LintKindUnnormalized, PolicyID: 1, Message: "resource /blogs/* should be stored as blogs/*", IsFixable: true},
+		{Kind: policies.LintKindRedundant, PolicyID: 2, RelatedPolicyID: 1, Message: "read on blogs/12/pages is covered by policy 1, write on /blogs/*", IsFixable: true},
+		{Kind: policies.LintKindRedundant, PolicyID: 3, RelatedPolicyID: 1, Message: "write on blogs/* is covered by policy 1, write on /blogs/*", IsFixable: true},
+		{Kind: policies.LintKindRedundant, PolicyID: 5, RelatedPolicyID: 1, Message: "write on blogs/1/settings is covered by policy 1, write on /blogs/*", IsFixable: true},
+		{Kind: policies.LintKindConflict, PolicyID: 5, RelatedPolicyID: 4, Message: "allow write on blogs/1/settings is denied by policy 4 on blogs/*/settings"},
+		{Kind: policies.LintKindRootGrant, PolicyID: 7, Message: "read is granted on every resource"},
+		{Kind: policies.LintKindRedundant, PolicyID: 8, RelatedPolicyID: 7, Message: "read on reports/* is covered by policy 7, read on *", IsFixable: true},
+		{Kind: policies.LintKindInvalid, PolicyID: 9, Message: "invalid condition at offset 6: expected value"},
+	}
+
+	t.Run("reports findings without changing anything", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().GetByAccount(gomock.Any(), int64(100)).Return(accountPolicies, nil)
+		service := policies.NewService(test.mockRepository)
+
+		findings, err := service.LintPolicies(t.Context(), &policies.LintPoliciesRequest{AccountID: 100})
+
+		assert.NoError(t, err)
+		assert.Equal(t, wantFindings, findings)
+	})
+
+	t.Run("fixes redundant and unnormalized policies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().GetByAccount(gomock.Any(), int64(100)).Return(accountPolicies, nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "2").Return(nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "3").Return(nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "5").Return(nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "8").Return(nil)
+		test.mockRepository.EXPECT().UpdateResource(gomock.Any(), int64(1), "blogs/*").Return(nil)
+		service := policies.NewService(test.mockRepository)
+
+		findings, err := service.LintPolicies(t.Context(), &policies.LintPoliciesRequest{AccountID: 100, Fix: true})
+
+		assert.NoError(t, err)
+		for _, finding := range findings {
+			assert.Equal(t, finding.IsFixable, finding.IsFixed, "policy %d %s", finding.PolicyID, finding.Kind)
+		}
+	})
+
+	t.Run("rolls back every fix when one fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		repositoryErr := errors.New("connection refused")
+		var transactionErr error
+		test.mockRepository.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fn func(policies.Repository) error) error {
+				transactionErr = fn(test.mockRepository)
+				return transactionErr
+			})
+		test.mockRepository.EXPECT().GetByAccount(gomock.Any(), int64(100)).Return(accountPolicies, nil)
+		// Policy 2 is deleted within the transaction, then deleting policy 3 fails and the transaction is rolled back.
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "2").Return(nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "3").Return(repositoryErr)
+		service := policies.NewService(test.mockRepository)
+
+		findings, err := service.LintPolicies(t.Context(), &policies.LintPoliciesRequest{AccountID: 100, Fix: true})
+
+		assert.ErrorIs(t, err, repositoryErr)
+		assert.ErrorIs(t, transactionErr, repositoryErr)
+		assert.Nil(t, findings)
+	})
+
+	t.Run("reports unregistered resources", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().GetByAccount(gomock.Any(), int64(100)).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1", Action: policies.ActionRead},
+			{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "reports/1", Action: policies.ActionRead},
+		}, nil)
+		registry := policies.NewResourceRegistry()
+		registry.Register(policies.ResourceTemplate{Template: "blogs/{blogID}", Actions: []policies.Action{policies.ActionRead}})
+		service := policies.NewService(test.mockRepository, policies.WithResourceRegistry(registry))
+
+		findings, err := service.LintPolicies(t.Context(), &policies.LintPoliciesRequest{AccountID: 100})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []policies.LintFinding{
+			{Kind: policies.LintKindUnregistered, PolicyID: 2, Message: "read on reports/1: resource matches no registered resource template"},
+		}, findings)
+	})
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	policies "github.com/adhikag24/policy-based-permission-model/domain/policies"
 	gomock "go.uber.org/mock/gomock"
@@ -70,18 +71,19 @@ func (mr *MockRepositoryMockRecorder) Delete(ctx, policyID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, policyID)
 }
 
-// DeleteByPrefix mocks base method.
-func (m *MockRepository) DeleteByPrefix(ctx context.Context, request *policies.DeleteByPrefixRequest) error {
+// DeleteExpired mocks base method.
+func (m *MockRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByPrefix", ctx, request)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteByPrefix indicates an expected call of DeleteByPrefix.
-func (mr *MockRepositoryMockRecorder) DeleteByPrefix(ctx, request any) *gomock.Call {
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockRepositoryMockRecorder) DeleteExpired(ctx, before any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPrefix", reflect.TypeOf((*MockRepository)(nil).DeleteByPrefix), ctx, request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockRepository)(nil).DeleteExpired), ctx, before)
 }
 
 // Get mocks base method.
@@ -98,3 +100,91 @@ func (mr *MockRepositoryMockRecorder) Get(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, request)
 }
+
+// GetByAccount mocks base method.
+func (m *MockRepository) GetByAccount(ctx context.Context, accountID int64) ([]policies.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAccount", ctx, accountID)
+	ret0, _ := ret[0].([]policies.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAccount indicates an expected call of GetByAccount.
+func (mr *MockRepositoryMockRecorder) GetByAccount(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccount", reflect.TypeOf((*MockRepository)(nil).GetByAccount), ctx, accountID)
+}
+
+// GetByResource mocks base method.
+func (m *MockRepository) GetByResource(ctx context.Context, request *policies.GetByResourceRequest) ([]policies.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByResource", ctx, request)
+	ret0, _ := ret[0].([]policies.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByResource indicates an expected call of GetByResource.
+func (mr *MockRepositoryMockRecorder) GetByResource(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByResource", reflect.TypeOf((*MockRepository)(nil).GetByResource), ctx, request)
+}
+
+// GetRevisions mocks base method.
+func (m *MockRepository) GetRevisions(ctx context.Context, request *policies.GetRevisionsRequest) ([]policies.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, request)
+	ret0, _ := ret[0].([]policies.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockRepositoryMockRecorder) GetRevisions(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockRepository)(nil).GetRevisions), ctx, request)
+}
+
+// GetRevisionsStart mocks base method.
+func (m *MockRepository) GetRevisionsStart(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisionsStart", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisionsStart indicates an expected call of GetRevisionsStart.
+func (mr *MockRepositoryMockRecorder) GetRevisionsStart(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisionsStart", reflect.TypeOf((*MockRepository)(nil).GetRevisionsStart), ctx)
+}
+
+// Transaction mocks base method.
+func (m *MockRepository) Transaction(ctx context.Context, fn func(policies.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockRepositoryMockRecorder) Transaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockRepository)(nil).Transaction), ctx, fn)
+}
+
+// UpdateResource mocks base method.
+func (m *MockRepository) UpdateResource(ctx context.Context, policyID int64, resource string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateResource", ctx, policyID, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateResource indicates an expected call of UpdateResource.
+func (mr *MockRepositoryMockRecorder) UpdateResource(ctx, policyID, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResource", reflect.TypeOf((*MockRepository)(nil).UpdateResource), ctx, policyID, resource)
+}
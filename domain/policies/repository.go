@@ -1,24 +1,53 @@
 package policies
 
-import "context"
+import (
+	"context"
+	"slices"
+	"time"
+)
 
+// Repository records a Revision for every policy it creates, deletes, or updates, attributed to ActorFromContext.
 type Repository interface {
 	Create(ctx context.Context, policy *Policy) (*Policy, error)
 	Delete(ctx context.Context, policyID string) error
 	Get(ctx context.Context, request *GetPolicyRequest) ([]Policy, error)
-	DeleteByPrefix(ctx context.Context, request *DeleteByPrefixRequest) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+	// GetByResource returns candidate policies of every principal that may match the resource.
+	// It may over-fetch wildcard patterns; callers match them precisely.
+	GetByResource(ctx context.Context, request *GetByResourceRequest) ([]Policy, error)
+	// GetByAccount returns every policy of every principal in the account.
+	GetByAccount(ctx context.Context, accountID int64) ([]Policy, error)
+	UpdateResource(ctx context.Context, policyID int64, resource string) error
+	GetRevisions(ctx context.Context, request *GetRevisionsRequest) ([]Revision, error)
+	// GetRevisionsStart returns when the first revision was recorded. Zero when none was.
+	GetRevisionsStart(ctx context.Context) (time.Time, error)
+	// Transaction runs fn with a repository whose writes are committed together, or not at all when fn returns an error.
+	Transaction(ctx context.Context, fn func(repo Repository) error) error
 }
 
-// Retreive policy based on AccountID, TeamMemberID, and Action.
+// Retreive policy based on AccountID, principal, and Actions.
+// Policies match when they target TeamMemberID or any of GroupIDs; zero values are ignored.
+// Actions holds the requested action along with the actions implying it.
 type GetPolicyRequest struct {
 	AccountID    int64
 	TeamMemberID int64
-	Action       Action
+	GroupIDs     []int64
+	Actions      []Action
 }
 
-type DeleteByPrefixRequest struct {
-	AccountID      int64
-	TeamMemberID   int64
-	ResourcePrefix string
-	Action         Action
+// matches reports whether Repository.Get returns the policy for the request. Repository overlays,
+// such as simulations and point-in-time checks, filter the policies they add with it.
+func (r *GetPolicyRequest) matches(policy Policy) bool {
+	isPrincipal := (policy.TeamMemberID != 0 && policy.TeamMemberID == r.TeamMemberID) ||
+		(policy.GroupID != 0 && slices.Contains(r.GroupIDs, policy.GroupID))
+	return policy.AccountID == r.AccountID && isPrincipal && slices.Contains(r.Actions, policy.Action)
+}
+
+// Retreive policies of any principal in AccountID whose resource equals Resource or has a wildcard.
+// IncludeDescendants also returns policies under Resource, for parent-read.
+type GetByResourceRequest struct {
+	AccountID          int64
+	Resource           string
+	Actions            []Action
+	IncludeDescendants bool
 }
@@ -0,0 +1,200 @@
+package policies
+
+import (
+	"slices"
+	"strings"
+)
+
+// Resource patterns are matched segment by segment:
+//   - *  matches exactly one segment, e.g. blogs/*/settings
+//   - ** matches zero or more segments, e.g. funnels/*/pages/**
+//   - a trailing * matches one or more segments, so blogs/* keeps covering blogs/1/pages/2
+const (
+	wildcardSegment          = "*"
+	recursiveWildcardSegment = "**"
+)
+
+// ResourcePath is a normalized resource or resource pattern. E.g., blogs/123/settings.
+type ResourcePath struct {
+	segments []string
+}
+
+// ParseResourcePath validates and normalizes a resource. Leading, trailing, and duplicate
+// slashes are dropped, so /blogs//123/ becomes blogs/123.
+func ParseResourcePath(resource string) (ResourcePath, error) {
+	var segments []string
+	for _, segment := range strings.Split(resource, "/") {
+		if segment == "" {
+			continue
+		}
+
+		// Wildcards are only allowed as whole segments. E.g., blog* is invalid.
+		if strings.Contains(segment, wildcardSegment) && segment != wildcardSegment && segment != recursiveWildcardSegment {
+			return ResourcePath{}, ErrInvalidResource
+		}
+
+		segments = append(segments, segment)
+	}
+
+	if len(segments) == 0 {
+		return ResourcePath{}, ErrInvalidResource
+	}
+
+	return ResourcePath{segments: segments}, nil
+}
+
+func (p ResourcePath) String() string {
+	return strings.Join(p.segments, "/")
+}
+
+func (p ResourcePath) Segments() []string {
+	return slices.Clone(p.segments)
+}
+
+func (p ResourcePath) Equal(other ResourcePath) bool {
+	return slices.Equal(p.segments, other.segments)
+}
+
+// IsRoot reports whether the path grants every resource. E.g., *
+func (p ResourcePath) IsRoot() bool {
+	return len(p.segments) == 1 && isWildcard(p.segments[0])
+}
+
+func (p ResourcePath) HasWildcard() bool {
+	return slices.ContainsFunc(p.segments, isWildcard)
+}
+
+// Covers reports whether every resource matched by other is also matched by p.
+// A concrete resource is a pattern without wildcards, so this also matches a requested
+// resource against a policy.
+func (p ResourcePath) Covers(other ResourcePath) bool {
+	a, b := p.matchSegments(), other.matchSegments()
+
+	memo := make(map[[2]int]bool)
+	var covers func(i, j int) bool
+	covers = func(i, j int) bool {
+		key := [2]int{i, j}
+		if result, ok := memo[key]; ok {
+			return result
+		}
+
+		var result bool
+		switch {
+		case i == len(a):
+			result = j == len(b)
+		case a[i] == recursiveWildcardSegment:
+			// Either match nothing, or absorb one more segment of the narrower pattern.
+			result = covers(i+1, j) || (j < len(b) && covers(i, j+1))
+		case j == len(b):
+			result = false
+		case b[j] == recursiveWildcardSegment:
+			// Only a recursive wildcard can cover a recursive wildcard.
+			result = false
+		case a[i] == wildcardSegment:
+			result = covers(i+1, j+1)
+		default:
+			result = a[i] == b[j] && covers(i+1, j+1)
+		}
+
+		memo[key] = result
+		return result
+	}
+
+	return covers(0, 0)
+}
+
+// Overlaps reports whether some resource is matched by both patterns.
+// E.g., blogs/*/settings overlaps blogs/1/**, but not blogs/1/pages.
+func (p ResourcePath) Overlaps(other ResourcePath) bool {
+	a, b := p.matchSegments(), other.matchSegments()
+
+	memo := make(map[[2]int]bool)
+	var overlaps func(i, j int) bool
+	overlaps = func(i, j int) bool {
+		key := [2]int{i, j}
+		if result, ok := memo[key]; ok {
+			return result
+		}
+
+		var result bool
+		switch {
+		case i == len(a) && j == len(b):
+			result = true
+		case i < len(a) && a[i] == recursiveWildcardSegment:
+			result = overlaps(i+1, j) || (j < len(b) && overlaps(i, j+1))
+		case j < len(b) && b[j] == recursiveWildcardSegment:
+			result = overlaps(i, j+1) || (i < len(a) && overlaps(i+1, j))
+		case i == len(a) || j == len(b):
+			result = false
+		default:
+			result = (a[i] == wildcardSegment || b[j] == wildcardSegment || a[i] == b[j]) && overlaps(i+1, j+1)
+		}
+
+		memo[key] = result
+		return result
+	}
+
+	return overlaps(0, 0)
+}
+
+// Ancestors returns the patterns strictly above p that parent-read makes readable,
+// from the top down. E.g., blogs/11/pages/12 -> blogs, blogs/11, blogs/11/pages.
+// An ancestor ending in * is skipped, since a trailing * would also match deeper resources,
+// and the walk stops at **, whose ancestor already matches everything below it.
+func (p ResourcePath) Ancestors() []ResourcePath {
+	var ancestors []ResourcePath
+	for i := 1; i < len(p.segments); i++ {
+		last := p.segments[i-1]
+		if last == recursiveWildcardSegment {
+			break
+		}
+		if last == wildcardSegment {
+			continue
+		}
+
+		ancestors = append(ancestors, ResourcePath{segments: slices.Clone(p.segments[:i])})
+	}
+
+	if i := slices.Index(p.segments, recursiveWildcardSegment); i >= 0 && i < len(p.segments)-1 {
+		ancestors = append(ancestors, ResourcePath{segments: slices.Clone(p.segments[:i+1])})
+	}
+
+	return ancestors
+}
+
+// CoversDescendantOf reports whether p matches some resource strictly under parent.
+// E.g., blogs/11/pages/12 and blogs/*/pages/12 are under blogs/11, but blogs/110 is not.
+func (p ResourcePath) CoversDescendantOf(parent ResourcePath) bool {
+	segments := p.matchSegments()
+	for i, segment := range parent.segments {
+		if i >= len(segments) {
+			return false
+		}
+
+		switch segments[i] {
+		case recursiveWildcardSegment:
+			return true
+		case wildcardSegment:
+			continue
+		}
+
+		if segments[i] != segment {
+			return false
+		}
+	}
+
+	return len(segments) > len(parent.segments)
+}
+
+// matchSegments expands a trailing * into * followed by **, i.e., one or more segments.
+func (p ResourcePath) matchSegments() []string {
+	if p.segments[len(p.segments)-1] != wildcardSegment {
+		return p.segments
+	}
+
+	return append(slices.Clone(p.segments), recursiveWildcardSegment)
+}
+
+func isWildcard(segment string) bool {
+	return segment == wildcardSegment || segment == recursiveWildcardSegment
+}
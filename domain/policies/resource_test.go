@@ -0,0 +1,121 @@
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResourcePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		want     string
+		wantErr  error
+	}{
+		{name: "already canonical", resource: "blogs/123/settings", want: "blogs/123/settings"},
+		{name: "leading slash", resource: "/blogs/*", want: "blogs/*"},
+		{name: "trailing slash", resource: "blogs/123/", want: "blogs/123"},
+		{name: "duplicate slashes", resource: "blogs//123///settings", want: "blogs/123/settings"},
+		{name: "root", resource: "*", want: "*"},
+		{name: "recursive wildcard", resource: "funnels/*/pages/**", want: "funnels/*/pages/**"},
+		{name: "empty", resource: "/", wantErr: policies.ErrInvalidResource},
+		{name: "partial wildcard", resource: "blogs/blog*", wantErr: policies.ErrInvalidResource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := policies.ParseResourcePath(tt.resource)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, path.String())
+		})
+	}
+}
+
+func TestResourcePathMatching(t *testing.T) {
+	mustParse := func(resource string) policies.ResourcePath {
+		path, err := policies.ParseResourcePath(resource)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", resource, err)
+		}
+		return path
+	}
+
+	t.Run("covers", func(t *testing.T) {
+		tests := []struct {
+			broader, narrower string
+			want              bool
+		}{
+			{"blogs/*", "blogs/1", true},
+			{"blogs/*", "blogs/1/pages/2", true},
+			{"blogs/*", "blogs", false},
+			{"blogs/*", "blogsettings/1", false},
+			{"blogs/*/settings", "blogs/1/settings", true},
+			{"blogs/*/settings", "blogs/1/pages", false},
+			{"funnels/*/pages/**", "funnels/1/pages", true},
+			{"funnels/*/pages/**", "funnels/1/pages/*", true},
+			{"funnels/*/pages/*", "funnels/1/pages/**", false},
+			{"*", "funnels/1", true},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.want, mustParse(tt.broader).Covers(mustParse(tt.narrower)), "%s covers %s", tt.broader, tt.narrower)
+		}
+	})
+
+	t.Run("covers descendant of", func(t *testing.T) {
+		tests := []struct {
+			resource, parent string
+			want             bool
+		}{
+			{"blogs/1/pages/2", "blogs/1", true},
+			{"blogs/11/pages/2", "blogs/1", false},
+			{"blogs/*/pages/2", "blogs/1", true},
+			{"blogs/1", "blogs/1", false},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.want, mustParse(tt.resource).CoversDescendantOf(mustParse(tt.parent)), "%s under %s", tt.resource, tt.parent)
+		}
+	})
+
+	t.Run("overlaps", func(t *testing.T) {
+		tests := []struct {
+			a, b string
+			want bool
+		}{
+			{"blogs/*/settings", "blogs/1/**", true},
+			{"blogs/*/settings", "blogs/1/pages", false},
+			{"blogs/1", "blogs/**", true},
+			{"blogs", "blogs/**", true},
+			{"blogs/*", "blogs/**", true},
+			{"blogs/*", "blogs", false},
+			{"funnels/1", "blogs/**", false},
+			{"*", "funnels/**", true},
+			{"**/settings", "blogs/1/**", true},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.want, mustParse(tt.a).Overlaps(mustParse(tt.b)), "%s overlaps %s", tt.a, tt.b)
+			assert.Equal(t, tt.want, mustParse(tt.b).Overlaps(mustParse(tt.a)), "%s overlaps %s", tt.b, tt.a)
+		}
+	})
+
+	t.Run("ancestors", func(t *testing.T) {
+		ancestors := func(resource string) []string {
+			var paths []string
+			for _, ancestor := range mustParse(resource).Ancestors() {
+				paths = append(paths, ancestor.String())
+			}
+			return paths
+		}
+
+		assert.Equal(t, []string{"blogs", "blogs/11", "blogs/11/pages"}, ancestors("blogs/11/pages/12"))
+		assert.Equal(t, []string{"funnels", "funnels/*/pages"}, ancestors("funnels/*/pages/1"))
+		assert.Equal(t, []string{"blogs", "blogs/**"}, ancestors("blogs/**/settings"))
+		assert.Equal(t, []string{"blogs"}, ancestors("blogs/*"))
+		assert.Nil(t, ancestors("*"))
+	})
+}
@@ -0,0 +1,160 @@
+package policies
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"time"
+)
+
+type RevisionOperation string
+
+const (
+	RevisionOperationCreated RevisionOperation = "created"
+	RevisionOperationDeleted RevisionOperation = "deleted"
+	RevisionOperationUpdated RevisionOperation = "updated"
+)
+
+// ActorSweeper is recorded on revisions of policies the Sweeper deletes.
+const ActorSweeper = "system:sweeper"
+
+// Revision is an immutable record of one policy mutation. Repository writes record
+// their revisions in the same transaction as the mutation.
+type Revision struct {
+	ID           int64
+	AccountID    int64
+	TeamMemberID int64
+	GroupID      int64
+	PolicyID     int64
+	Operation    RevisionOperation
+	Actor        string  // Who made the change, see WithActor. Empty when unknown, such as for API requests.
+	Before       *Policy // Nil when created.
+	After        *Policy // Nil when deleted.
+	CreatedAt    time.Time
+}
+
+// GetRevisionsRequest lists revisions oldest first. Revisions match when they target TeamMemberID or any of
+// GroupIDs; when both are zero, the revisions of every principal are listed.
+type GetRevisionsRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+	GroupIDs     []int64
+	// FromRevisionID skips revisions with a lower ID. Zero lists every revision.
+	FromRevisionID int64
+	// After only lists revisions recorded after the time. Zero lists every revision.
+	After time.Time
+}
+
+// RollbackRequest restores the member's own policies to the state right after RevisionID.
+type RollbackRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+	RevisionID   int64
+}
+
+// RollbackResult holds the policies deleted by the rollback and the outcome of every policy it recreated.
+// Recreated policies get new IDs.
+type RollbackResult struct {
+	DeletedPolicyIDs []int64
+	Created          []ImportedPolicy
+}
+
+type actorKey struct{}
+
+// WithActor attributes the policy mutations made with ctx to actor. E.g., member:2
+// Revisions are an audit trail, so actor must come from an authenticated identity or the server itself,
+// never from a value the client supplies. Requests carry no authenticated identity yet.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+func (s *service) ListRevisions(ctx context.Context, request *GetRevisionsRequest) ([]Revision, error) {
+	return s.repo.GetRevisions(ctx, request)
+}
+
+// Rollback computes the member's policies at the revision by undoing every later revision on the
+// current policies, so history from before revisions were recorded is not needed. Policies missing
+// from that state are deleted, and removed ones are recreated through CreatePolicy, in their original order.
+// Policies are compared by ID only: an updated resource is not reverted, since updates only normalize it.
+// It runs in a single transaction, so a failure leaves the policies as they were.
+func (s *service) Rollback(ctx context.Context, request *RollbackRequest) (*RollbackResult, error) {
+	var result *RollbackResult
+	err := s.inTransaction(ctx, func(s *service) error {
+		var err error
+		result, err = s.rollback(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *service) rollback(ctx context.Context, request *RollbackRequest) (*RollbackResult, error) {
+	revisions, err := s.repo.GetRevisions(ctx, &GetRevisionsRequest{
+		AccountID:      request.AccountID,
+		TeamMemberID:   request.TeamMemberID,
+		FromRevisionID: request.RevisionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 || revisions[0].ID != request.RevisionID {
+		return nil, ErrRevisionNotFound
+	}
+
+	current, err := s.repo.Get(ctx, &GetPolicyRequest{
+		AccountID:    request.AccountID,
+		TeamMemberID: request.TeamMemberID,
+		Actions:      s.actions.AllActions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	target := slices.Clone(current)
+	for _, revision := range slices.Backward(revisions[1:]) {
+		target = slices.DeleteFunc(target, func(policy Policy) bool {
+			return policy.ID == revision.PolicyID
+		})
+		if revision.Before != nil {
+			target = append(target, *revision.Before)
+		}
+	}
+	slices.SortFunc(target, func(a, b Policy) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	result := &RollbackResult{}
+	for _, policy := range current {
+		if slices.ContainsFunc(target, func(targetPolicy Policy) bool { return targetPolicy.ID == policy.ID }) {
+			continue
+		}
+		if err := s.repo.Delete(ctx, strconv.FormatInt(policy.ID, 10)); err != nil {
+			return nil, err
+		}
+		result.DeletedPolicyIDs = append(result.DeletedPolicyIDs, policy.ID)
+	}
+
+	for _, policy := range target {
+		if slices.ContainsFunc(current, func(currentPolicy Policy) bool { return currentPolicy.ID == policy.ID }) {
+			continue
+		}
+
+		policy.ID = 0
+		imported, err := s.createImportedPolicy(ctx, &policy)
+		if err != nil {
+			return nil, err
+		}
+		result.Created = append(result.Created, imported)
+	}
+
+	return result, nil
+}
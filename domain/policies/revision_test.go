@@ -0,0 +1,107 @@
+package policies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRollback(t *testing.T) {
+	t.Run("undoes every later revision through the normal create rules", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		blogs := policies.Policy{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow}
+		funnels := policies.Policy{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "funnels/1", Action: policies.ActionWrite, Effect: policies.EffectAllow}
+		pages := policies.Policy{ID: 3, AccountID: 100, TeamMemberID: 200, Resource: "pages/*", Action: policies.ActionWrite, Effect: policies.EffectAllow}
+		normalizedBlogs := blogs
+		unnormalizedBlogs := blogs
+		unnormalizedBlogs.Resource = "/blogs/*"
+		test.mockRepository.EXPECT().GetRevisions(gomock.Any(), &policies.GetRevisionsRequest{
+			AccountID:      100,
+			TeamMemberID:   200,
+			FromRevisionID: 10,
+		}).Return([]policies.Revision{
+			{ID: 10, PolicyID: 2, Operation: policies.RevisionOperationCreated, After: &funnels},
+			{ID: 11, PolicyID: 1, Operation: policies.RevisionOperationUpdated, Before: &unnormalizedBlogs, After: &normalizedBlogs},
+			{ID: 12, PolicyID: 2, Operation: policies.RevisionOperationDeleted, Before: &funnels},
+			{ID: 13, PolicyID: 3, Operation: policies.RevisionOperationCreated, After: &pages},
+		}, nil)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Actions:      []policies.Action{policies.ActionRead, policies.ActionWrite},
+		}).Return([]policies.Policy{blogs, pages}, nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "3").Return(nil)
+		// Recreating funnels/1 goes through CreatePolicy.
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{blogs}, nil)
+		recreated := funnels
+		recreated.ID = 4
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Cond(func(policy *policies.Policy) bool {
+			return policy.ID == 0 && policy.Resource == "funnels/1"
+		})).Return(&recreated, nil)
+		service := policies.NewService(test.mockRepository)
+
+		result, err := service.Rollback(t.Context(), &policies.RollbackRequest{AccountID: 100, TeamMemberID: 200, RevisionID: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &policies.RollbackResult{
+			DeletedPolicyIDs: []int64{3},
+			Created: []policies.ImportedPolicy{
+				{Resource: "funnels/1", Action: policies.ActionWrite, Status: policies.ImportStatusCreated, PolicyID: 4},
+			},
+		}, result)
+	})
+
+	t.Run("rolls back the deletions when recreating a policy fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		repositoryErr := errors.New("connection refused")
+		var transactionErr error
+		test.mockRepository.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fn func(policies.Repository) error) error {
+				transactionErr = fn(test.mockRepository)
+				return transactionErr
+			})
+		funnels := policies.Policy{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "funnels/1", Action: policies.ActionWrite, Effect: policies.EffectAllow}
+		pages := policies.Policy{ID: 3, AccountID: 100, TeamMemberID: 200, Resource: "pages/*", Action: policies.ActionWrite, Effect: policies.EffectAllow}
+		test.mockRepository.EXPECT().GetRevisions(gomock.Any(), gomock.Any()).Return([]policies.Revision{
+			{ID: 10, PolicyID: 1, Operation: policies.RevisionOperationCreated},
+			{ID: 11, PolicyID: 2, Operation: policies.RevisionOperationDeleted, Before: &funnels},
+			{ID: 12, PolicyID: 3, Operation: policies.RevisionOperationCreated, After: &pages},
+		}, nil)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{pages}, nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "3").Return(nil)
+		// Recreating funnels/1 fails, so deleting pages/* must not be committed either.
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, repositoryErr)
+		service := policies.NewService(test.mockRepository)
+
+		result, err := service.Rollback(t.Context(), &policies.RollbackRequest{AccountID: 100, TeamMemberID: 200, RevisionID: 10})
+
+		assert.ErrorIs(t, err, repositoryErr)
+		assert.ErrorIs(t, transactionErr, repositoryErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("rejects a revision of another member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().GetRevisions(gomock.Any(), gomock.Any()).Return([]policies.Revision{
+			{ID: 11, PolicyID: 1, Operation: policies.RevisionOperationCreated},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		result, err := service.Rollback(t.Context(), &policies.RollbackRequest{AccountID: 100, TeamMemberID: 200, RevisionID: 10})
+
+		assert.ErrorIs(t, err, policies.ErrRevisionNotFound)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,477 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule is a statement of the textual policy language, one per line.
+//
+// Grammar:
+//
+//	rule      := effect actions "on" resources "to" principal "in" "account" id validity ["when" condition]
+//	effect    := "allow" | "deny"
+//	actions   := action ("," action)*
+//	resources := resource ("," resource)*
+//	principal := ("member" | "group") id
+//	validity  := ["from" timestamp] ["until" timestamp]
+//
+// Timestamps are RFC 3339, and the condition runs to the end of the line, see ParseCondition.
+// Blank lines and lines starting with # are ignored. E.g.,
+//
+//	# Editors of blog 12.
+//	allow write on blogs/12/* to member 2 in account 1 when time < 18:00
+//	deny write on blogs/12/settings to group 5 in account 1 until 2026-01-31T18:00:00Z
+type Rule struct {
+	Effect       Effect
+	Actions      []Action
+	Resources    []string
+	AccountID    int64
+	TeamMemberID int64
+	GroupID      int64
+	NotBefore    *time.Time
+	ExpiresAt    *time.Time
+	Condition    string
+	Line         int // Where the rule is in the parsed text, starting at 1.
+}
+
+// RuleSyntaxError reports where rule text fails to parse.
+// Lines and columns start at 1, and columns count characters rather than bytes.
+type RuleSyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *RuleSyntaxError) Error() string {
+	return fmt.Sprintf("invalid rule at line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+func (e *RuleSyntaxError) Unwrap() error {
+	return ErrInvalidRule
+}
+
+// ParseRules parses rule text. Resources and conditions are checked for syntax only;
+// registered actions and resources are checked when the policies are created.
+func ParseRules(text string) ([]Rule, error) {
+	tokens, err := tokenizeRules(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ruleParser{tokens: tokens}
+	var rules []Rule
+	for p.peek().kind != ruleTokenEOF {
+		if p.peek().kind == ruleTokenNewline {
+			p.next()
+			continue
+		}
+
+		rule, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// NewRule renders a policy as a rule, e.g., to format stored policies.
+func NewRule(policy Policy) Rule {
+	effect := policy.Effect
+	if effect == "" {
+		effect = EffectAllow
+	}
+
+	return Rule{
+		Effect:       effect,
+		Actions:      []Action{policy.Action},
+		Resources:    []string{policy.Resource},
+		AccountID:    policy.AccountID,
+		TeamMemberID: policy.TeamMemberID,
+		GroupID:      policy.GroupID,
+		NotBefore:    policy.NotBefore,
+		ExpiresAt:    policy.ExpiresAt,
+		Condition:    policy.Condition,
+	}
+}
+
+// Policies expands the rule into one policy per action and resource.
+func (r Rule) Policies() []Policy {
+	statement := DocumentStatement{
+		Effect:    r.Effect,
+		Actions:   r.Actions,
+		Resources: r.Resources,
+		Condition: r.Condition,
+		NotBefore: r.NotBefore,
+		ExpiresAt: r.ExpiresAt,
+	}
+
+	policies := statement.Policies()
+	for i := range policies {
+		policies[i].AccountID = r.AccountID
+		policies[i].TeamMemberID = r.TeamMemberID
+		policies[i].GroupID = r.GroupID
+	}
+	return policies
+}
+
+// String formats the rule canonically: single spaces, ", " between list items, and UTC timestamps.
+func (r Rule) String() string {
+	var b strings.Builder
+	b.WriteString(string(r.Effect))
+	b.WriteString(" ")
+	for i, action := range r.Actions {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(string(action))
+	}
+	b.WriteString(" on ")
+	b.WriteString(strings.Join(r.Resources, ", "))
+
+	if r.GroupID != 0 {
+		fmt.Fprintf(&b, " to group %d", r.GroupID)
+	} else {
+		fmt.Fprintf(&b, " to member %d", r.TeamMemberID)
+	}
+	fmt.Fprintf(&b, " in account %d", r.AccountID)
+
+	if r.NotBefore != nil {
+		b.WriteString(" from " + r.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if r.ExpiresAt != nil {
+		b.WriteString(" until " + r.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if r.Condition != "" {
+		b.WriteString(" when " + formatCondition(r.Condition))
+	}
+
+	return b.String()
+}
+
+// FormatRules formats rules one per line.
+func FormatRules(rules []Rule) string {
+	var b strings.Builder
+	for _, rule := range rules {
+		b.WriteString(rule.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatCondition normalizes spacing between condition tokens. E.g., (time<18:00)&&!(ip in "10.0.0.0/8")
+// becomes (time < 18:00) && !(ip in "10.0.0.0/8"). Invalid conditions are returned as is.
+func formatCondition(expression string) string {
+	tokens, err := tokenizeCondition(expression)
+	if err != nil {
+		return expression
+	}
+
+	var b strings.Builder
+	var previous conditionToken
+	for i, tok := range tokens {
+		if tok.kind == tokenEOF {
+			break
+		}
+		if i > 0 && previous.kind != tokenLeftParen && previous.kind != tokenNot && tok.kind != tokenRightParen {
+			b.WriteString(" ")
+		}
+
+		if tok.kind == tokenString {
+			b.WriteString(`"` + tok.text + `"`)
+		} else {
+			b.WriteString(tok.text)
+		}
+		previous = tok
+	}
+
+	return b.String()
+}
+
+// CompiledRules is rule text validated like CreatePolicy, without saving anything.
+type CompiledRules struct {
+	Rules    []Rule
+	Policies []Policy // Normalized, in rule order. E.g., /blogs/* becomes blogs/*
+}
+
+func (s *service) CompileRules(ctx context.Context, text string) (*CompiledRules, error) {
+	rules, err := ParseRules(text)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &CompiledRules{Rules: rules}
+	for _, rule := range rules {
+		for _, policy := range rule.Policies() {
+			if _, err := s.validatePolicy(&policy); err != nil {
+				return nil, fmt.Errorf("rule at line %d: %w", rule.Line, err)
+			}
+			compiled.Policies = append(compiled.Policies, policy)
+		}
+	}
+
+	return compiled, nil
+}
+
+type ruleTokenKind int
+
+const (
+	ruleTokenEOF ruleTokenKind = iota
+	ruleTokenNewline
+	ruleTokenWord
+	ruleTokenComma
+	ruleTokenCondition // Raw text following when, up to the end of the line.
+)
+
+type ruleToken struct {
+	kind   ruleTokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t ruleToken) describe() string {
+	switch t.kind {
+	case ruleTokenEOF:
+		return "end of input"
+	case ruleTokenNewline:
+		return "end of line"
+	case ruleTokenComma:
+		return `","`
+	}
+	return strconv.Quote(t.text)
+}
+
+// tokenizeRules splits words on whitespace and commas, so resources need no quoting.
+func tokenizeRules(text string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lineNumber := i + 1
+		line = strings.TrimSuffix(line, "\r")
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		column := 1
+		hasTokens := false
+		for offset := 0; offset < len(line); {
+			r, size := utf8.DecodeRuneInString(line[offset:])
+			switch {
+			case r == ' ' || r == '\t':
+				offset += size
+				column++
+			case r == ',':
+				tokens = append(tokens, ruleToken{kind: ruleTokenComma, text: ",", line: lineNumber, column: column})
+				hasTokens = true
+				offset += size
+				column++
+			case unicode.IsControl(r) || r == utf8.RuneError:
+				return nil, &RuleSyntaxError{Line: lineNumber, Column: column, Message: fmt.Sprintf("unexpected character %q", r)}
+			default:
+				start, startColumn := offset, column
+				for offset < len(line) {
+					r, size := utf8.DecodeRuneInString(line[offset:])
+					if r == ' ' || r == '\t' || r == ',' || unicode.IsControl(r) {
+						break
+					}
+					offset += size
+					column++
+				}
+				word := line[start:offset]
+				tokens = append(tokens, ruleToken{kind: ruleTokenWord, text: word, line: lineNumber, column: startColumn})
+				hasTokens = true
+
+				if word == "when" {
+					// The condition has its own syntax, so it is handed to ParseCondition as a whole.
+					rest := line[offset:]
+					trimmed := strings.TrimLeft(rest, " \t")
+					column += utf8.RuneCountInString(rest[:len(rest)-len(trimmed)])
+					tokens = append(tokens, ruleToken{kind: ruleTokenCondition, text: strings.TrimRight(trimmed, " \t"), line: lineNumber, column: column})
+					offset = len(line)
+				}
+			}
+		}
+
+		if hasTokens {
+			tokens = append(tokens, ruleToken{kind: ruleTokenNewline, line: lineNumber, column: column})
+		}
+	}
+
+	eof := ruleToken{kind: ruleTokenEOF, line: len(lines), column: 1}
+	return append(tokens, eof), nil
+}
+
+var ruleKeywords = []string{"allow", "deny", "on", "to", "member", "group", "in", "account", "from", "until", "when"}
+
+type ruleParser struct {
+	tokens   []ruleToken
+	position int
+}
+
+func (p *ruleParser) peek() ruleToken {
+	return p.tokens[p.position]
+}
+
+func (p *ruleParser) next() ruleToken {
+	tok := p.tokens[p.position]
+	if tok.kind != ruleTokenEOF {
+		p.position++
+	}
+	return tok
+}
+
+func (p *ruleParser) errorAt(tok ruleToken, message string) error {
+	return &RuleSyntaxError{Line: tok.line, Column: tok.column, Message: message}
+}
+
+func (p *ruleParser) expectKeyword(keyword string) error {
+	if tok := p.next(); tok.kind != ruleTokenWord || tok.text != keyword {
+		return p.errorAt(tok, fmt.Sprintf("expected %s, found %s", keyword, tok.describe()))
+	}
+	return nil
+}
+
+func (p *ruleParser) acceptKeyword(keyword string) bool {
+	if tok := p.peek(); tok.kind == ruleTokenWord && tok.text == keyword {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *ruleParser) parseRule() (Rule, error) {
+	effect := p.next()
+	rule := Rule{Effect: Effect(effect.text), Line: effect.line}
+	if effect.kind != ruleTokenWord || (rule.Effect != EffectAllow && rule.Effect != EffectDeny) {
+		return Rule{}, p.errorAt(effect, fmt.Sprintf("expected allow or deny, found %s", effect.describe()))
+	}
+
+	actions, err := p.parseList("action")
+	if err != nil {
+		return Rule{}, err
+	}
+	for _, action := range actions {
+		rule.Actions = append(rule.Actions, Action(action.text))
+	}
+
+	if err := p.expectKeyword("on"); err != nil {
+		return Rule{}, err
+	}
+	resources, err := p.parseList("resource")
+	if err != nil {
+		return Rule{}, err
+	}
+	for _, resource := range resources {
+		if _, err := ParseResourcePath(resource.text); err != nil {
+			return Rule{}, p.errorAt(resource, fmt.Sprintf("invalid resource %q", resource.text))
+		}
+		rule.Resources = append(rule.Resources, resource.text)
+	}
+
+	if err := p.expectKeyword("to"); err != nil {
+		return Rule{}, err
+	}
+	switch principal := p.next(); {
+	case principal.kind == ruleTokenWord && principal.text == "member":
+		rule.TeamMemberID, err = p.parseID("member")
+	case principal.kind == ruleTokenWord && principal.text == "group":
+		rule.GroupID, err = p.parseID("group")
+	default:
+		err = p.errorAt(principal, fmt.Sprintf("expected member or group, found %s", principal.describe()))
+	}
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if err := p.expectKeyword("in"); err != nil {
+		return Rule{}, err
+	}
+	if err := p.expectKeyword("account"); err != nil {
+		return Rule{}, err
+	}
+	if rule.AccountID, err = p.parseID("account"); err != nil {
+		return Rule{}, err
+	}
+
+	if p.acceptKeyword("from") {
+		if rule.NotBefore, err = p.parseTimestamp(); err != nil {
+			return Rule{}, err
+		}
+	}
+	if p.acceptKeyword("until") {
+		until := p.peek()
+		if rule.ExpiresAt, err = p.parseTimestamp(); err != nil {
+			return Rule{}, err
+		}
+		if rule.NotBefore != nil && !rule.ExpiresAt.After(*rule.NotBefore) {
+			return Rule{}, p.errorAt(until, "until must be after from")
+		}
+	}
+
+	if p.acceptKeyword("when") {
+		condition := p.next()
+		if condition.text == "" {
+			return Rule{}, p.errorAt(condition, "expected condition")
+		}
+		if _, err := ParseCondition(condition.text); err != nil {
+			var conditionErr *ConditionError
+			if errors.As(err, &conditionErr) {
+				column := condition.column + utf8.RuneCountInString(condition.text[:min(conditionErr.Offset, len(condition.text))])
+				return Rule{}, &RuleSyntaxError{Line: condition.line, Column: column, Message: conditionErr.Message}
+			}
+			return Rule{}, p.errorAt(condition, err.Error())
+		}
+		rule.Condition = condition.text
+	}
+
+	if end := p.next(); end.kind != ruleTokenNewline && end.kind != ruleTokenEOF {
+		return Rule{}, p.errorAt(end, fmt.Sprintf("expected end of rule, found %s", end.describe()))
+	}
+
+	return rule, nil
+}
+
+// parseList parses comma separated words. Keywords are rejected so a missing item is reported where it is missing.
+func (p *ruleParser) parseList(item string) ([]ruleToken, error) {
+	var items []ruleToken
+	for {
+		tok := p.next()
+		if tok.kind != ruleTokenWord || slices.Contains(ruleKeywords, tok.text) {
+			return nil, p.errorAt(tok, fmt.Sprintf("expected %s, found %s", item, tok.describe()))
+		}
+		items = append(items, tok)
+
+		if p.peek().kind != ruleTokenComma {
+			return items, nil
+		}
+		p.next()
+	}
+}
+
+func (p *ruleParser) parseID(name string) (int64, error) {
+	tok := p.next()
+	id, err := strconv.ParseInt(tok.text, 10, 64)
+	if tok.kind != ruleTokenWord || err != nil || id <= 0 {
+		return 0, p.errorAt(tok, fmt.Sprintf("expected %s ID, found %s", name, tok.describe()))
+	}
+	return id, nil
+}
+
+func (p *ruleParser) parseTimestamp() (*time.Time, error) {
+	tok := p.next()
+	timestamp, err := time.Parse(time.RFC3339, tok.text)
+	if tok.kind != ruleTokenWord || err != nil {
+		return nil, p.errorAt(tok, fmt.Sprintf("expected RFC 3339 timestamp, found %s", tok.describe()))
+	}
+	return &timestamp, nil
+}
@@ -0,0 +1,128 @@
+package policies_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseRules(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC)
+
+	rules, err := policies.ParseRules(`
+# Editors of blog 12.
+allow write on blogs/12/* to member 2 in account 1 when time < 18:00
+  deny read,write on blogs/12/settings ,funnels/* to group 5 in account 1 until 2026-01-31T18:00:00Z
+`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []policies.Rule{
+		{
+			Effect:       policies.EffectAllow,
+			Actions:      []policies.Action{policies.ActionWrite},
+			Resources:    []string{"blogs/12/*"},
+			AccountID:    1,
+			TeamMemberID: 2,
+			Condition:    "time < 18:00",
+			Line:         3,
+		},
+		{
+			Effect:    policies.EffectDeny,
+			Actions:   []policies.Action{policies.ActionRead, policies.ActionWrite},
+			Resources: []string{"blogs/12/settings", "funnels/*"},
+			AccountID: 1,
+			GroupID:   5,
+			ExpiresAt: &expiresAt,
+			Line:      4,
+		},
+	}, rules)
+
+	assert.Equal(t, []policies.Policy{
+		{AccountID: 1, GroupID: 5, Resource: "blogs/12/settings", Action: policies.ActionRead, Effect: policies.EffectDeny, ExpiresAt: &expiresAt},
+		{AccountID: 1, GroupID: 5, Resource: "funnels/*", Action: policies.ActionRead, Effect: policies.EffectDeny, ExpiresAt: &expiresAt},
+		{AccountID: 1, GroupID: 5, Resource: "blogs/12/settings", Action: policies.ActionWrite, Effect: policies.EffectDeny, ExpiresAt: &expiresAt},
+		{AccountID: 1, GroupID: 5, Resource: "funnels/*", Action: policies.ActionWrite, Effect: policies.EffectDeny, ExpiresAt: &expiresAt},
+	}, rules[1].Policies())
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		wantLine   int
+		wantColumn int
+	}{
+		{name: "unknown effect", text: "permit read on blogs/* to member 2 in account 1", wantLine: 1, wantColumn: 1},
+		{name: "missing on", text: "allow read blogs/* to member 2 in account 1", wantLine: 1, wantColumn: 12},
+		{name: "missing resource", text: "allow read on to member 2 in account 1", wantLine: 1, wantColumn: 15},
+		{name: "trailing comma", text: "allow read, on blogs/* to member 2 in account 1", wantLine: 1, wantColumn: 13},
+		{name: "invalid resource", text: "allow read on blogs/a* to member 2 in account 1", wantLine: 1, wantColumn: 15},
+		{name: "invalid principal", text: "allow read on blogs/* to role 2 in account 1", wantLine: 1, wantColumn: 26},
+		{name: "invalid member id", text: "allow read on blogs/* to member two in account 1", wantLine: 1, wantColumn: 33},
+		{name: "missing account", text: "\nallow read on blogs/* to member 2", wantLine: 2, wantColumn: 34},
+		{name: "invalid timestamp", text: "allow read on blogs/* to member 2 in account 1 until tomorrow", wantLine: 1, wantColumn: 54},
+		{name: "expires before start", text: "allow read on blogs/* to member 2 in account 1 from 2026-02-01T00:00:00Z until 2026-01-01T00:00:00Z", wantLine: 1, wantColumn: 80},
+		{name: "invalid condition", text: "allow read on blogs/* to member 2 in account 1 when time 18:00", wantLine: 1, wantColumn: 58},
+		{name: "empty condition", text: "allow read on blogs/* to member 2 in account 1 when", wantLine: 1, wantColumn: 52},
+		{name: "trailing words", text: "allow read on blogs/* to member 2 in account 1 please", wantLine: 1, wantColumn: 48},
+		{name: "multibyte column", text: "allow read on blogs/* to member 2 in account 1 when team == \"ü\" &&", wantLine: 1, wantColumn: 67},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := policies.ParseRules(tt.text)
+			assert.ErrorIs(t, err, policies.ErrInvalidRule)
+
+			var syntaxErr *policies.RuleSyntaxError
+			if assert.ErrorAs(t, err, &syntaxErr) {
+				assert.Equal(t, tt.wantLine, syntaxErr.Line)
+				assert.Equal(t, tt.wantColumn, syntaxErr.Column)
+			}
+		})
+	}
+}
+
+func TestFormatRules(t *testing.T) {
+	rules, err := policies.ParseRules(`allow   read ,write on blogs/*   to member 2 in account 1 from 2026-01-01T09:00:00+07:00 when (time<18:00)&&!(ip in "10.0.0.0/8")
+deny write on blogs/1/settings to group 5 in account 1`)
+	assert.NoError(t, err)
+
+	formatted := policies.FormatRules(rules)
+
+	assert.Equal(t, `allow read, write on blogs/* to member 2 in account 1 from 2026-01-01T02:00:00Z when (time < 18:00) && !(ip in "10.0.0.0/8")
+deny write on blogs/1/settings to group 5 in account 1
+`, formatted)
+
+	reparsed, err := policies.ParseRules(formatted)
+	assert.NoError(t, err)
+	assert.Equal(t, formatted, policies.FormatRules(reparsed))
+
+	assert.Equal(t, "allow read on funnels/* to member 2 in account 1", policies.NewRule(policies.Policy{
+		AccountID:    1,
+		TeamMemberID: 2,
+		Resource:     "funnels/*",
+		Action:       policies.ActionRead,
+	}).String())
+}
+
+func TestCompileRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	test := setup(ctrl)
+	// No repository expectations: compiling never reads or writes policies.
+	service := policies.NewService(test.mockRepository)
+
+	compiled, err := service.CompileRules(t.Context(), "allow read on /blogs/* to member 2 in account 1")
+	assert.NoError(t, err)
+	assert.Equal(t, []policies.Policy{
+		{AccountID: 1, TeamMemberID: 2, Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow},
+	}, compiled.Policies)
+
+	compiled, err = service.CompileRules(t.Context(), "allow read on blogs/* to member 2 in account 1\nallow publish on blogs/* to member 2 in account 1")
+	assert.ErrorIs(t, err, policies.ErrUnknownAction)
+	assert.ErrorContains(t, err, "line 2")
+	assert.Nil(t, compiled)
+}
@@ -0,0 +1,100 @@
+package policies
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ResourceTemplate describes a resource a domain module checks permissions on.
+// Placeholders are whole segments wrapped in braces. E.g., blogs/{blogID}/pages/{pageID}
+type ResourceTemplate struct {
+	Template string
+	Actions  []Action
+}
+
+// Build fills the placeholders in order. E.g., blogs/{blogID}/pages/{pageID} with 1, 2 -> blogs/1/pages/2
+func (t ResourceTemplate) Build(values ...string) string {
+	segments := strings.Split(t.Template, "/")
+	for i, segment := range segments {
+		if isPlaceholder(segment) && len(values) > 0 {
+			segments[i], values = values[0], values[1:]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// matches reports whether some concrete resource is matched by both the template and the pattern.
+func (t ResourceTemplate) matches(pattern ResourcePath) bool {
+	a, b := pattern.matchSegments(), strings.Split(t.Template, "/")
+
+	var matches func(i, j int) bool
+	matches = func(i, j int) bool {
+		switch {
+		case i == len(a):
+			return j == len(b)
+		case a[i] == recursiveWildcardSegment:
+			return matches(i+1, j) || (j < len(b) && matches(i, j+1))
+		case j == len(b):
+			return false
+		case a[i] == wildcardSegment || isPlaceholder(b[j]):
+			return matches(i+1, j+1)
+		default:
+			return a[i] == b[j] && matches(i+1, j+1)
+		}
+	}
+
+	return matches(0, 0)
+}
+
+func isPlaceholder(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// ResourceRegistry holds the resource templates registered by domain modules at startup.
+type ResourceRegistry struct {
+	mu        sync.RWMutex
+	templates []ResourceTemplate
+}
+
+func NewResourceRegistry() *ResourceRegistry {
+	return &ResourceRegistry{}
+}
+
+func (r *ResourceRegistry) Register(templates ...ResourceTemplate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.templates = append(r.templates, templates...)
+}
+
+func (r *ResourceRegistry) Templates() []ResourceTemplate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.templates)
+}
+
+// Validate checks that the policy resource addresses at least one registered template,
+// and that one of those templates allows the action.
+func (r *ResourceRegistry) Validate(resource ResourcePath, action Action) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	isMatched := false
+	for _, template := range r.templates {
+		if !template.matches(resource) {
+			continue
+		}
+
+		isMatched = true
+		if slices.Contains(template.Actions, action) {
+			return nil
+		}
+	}
+
+	if !isMatched {
+		return ErrUnknownResource
+	}
+	return ErrUnknownAction
+}
@@ -0,0 +1,57 @@
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceTemplateBuild(t *testing.T) {
+	template := policies.ResourceTemplate{Template: "blogs/{blogID}/pages/{pageID}"}
+
+	assert.Equal(t, "blogs/1/pages/2", template.Build("1", "2"))
+	assert.Equal(t, "blogs/1/pages/{pageID}", template.Build("1"))
+}
+
+func TestResourceRegistryValidate(t *testing.T) {
+	registry := policies.NewResourceRegistry()
+	registry.Register(
+		policies.ResourceTemplate{Template: "blogs/{blogID}", Actions: []policies.Action{policies.ActionRead, policies.ActionWrite}},
+		policies.ResourceTemplate{Template: "blogs/{blogID}/pages/{pageID}", Actions: []policies.Action{policies.ActionRead, policies.ActionPublish}},
+		policies.ResourceTemplate{Template: "funnels/{funnelID}", Actions: []policies.Action{policies.ActionRead}},
+	)
+
+	tests := []struct {
+		name     string
+		resource string
+		action   policies.Action
+		want     error
+	}{
+		{name: "concrete resource", resource: "blogs/1", action: policies.ActionWrite},
+		{name: "concrete nested resource", resource: "blogs/1/pages/2", action: policies.ActionPublish},
+		{name: "trailing wildcard addresses descendants", resource: "blogs/*", action: policies.ActionPublish},
+		{name: "mid-path wildcard", resource: "blogs/*/pages/2", action: policies.ActionRead},
+		{name: "recursive wildcard", resource: "**/pages/*", action: policies.ActionPublish},
+		{name: "root resource", resource: "*", action: policies.ActionRead},
+		{name: "unknown resource type", resource: "articles/1", action: policies.ActionRead, want: policies.ErrUnknownResource},
+		{name: "unknown nested resource", resource: "blogs/1/comments/2", action: policies.ActionRead, want: policies.ErrUnknownResource},
+		{name: "bare resource type", resource: "funnels", action: policies.ActionRead, want: policies.ErrUnknownResource},
+		{name: "action not allowed on template", resource: "blogs/1", action: policies.ActionPublish, want: policies.ErrUnknownAction},
+		{name: "action not allowed on any matched template", resource: "funnels/*", action: policies.ActionWrite, want: policies.ErrUnknownAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := policies.ParseResourcePath(tt.resource)
+			assert.NoError(t, err)
+
+			err = registry.Validate(resource, tt.action)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
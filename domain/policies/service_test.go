@@ -1,7 +1,12 @@
 package policies_test
 
 import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/adhikag24/policy-based-permission-model/domain/policies"
 	mockRepository "github.com/adhikag24/policy-based-permission-model/domain/policies/mocks"
@@ -19,15 +24,71 @@ func setup(ctrl *gomock.Controller) *test {
 	}
 }
 
+// expectTransaction runs the transaction body on the mock repository itself.
+func (test *test) expectTransaction() {
+	test.mockRepository.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(policies.Repository) error) error {
+			return fn(test.mockRepository)
+		})
+}
+
+type stubGrantProvider []policies.Policy
+
+func (p stubGrantProvider) GetGrants(_ context.Context, _ *policies.GetPolicyRequest) ([]policies.Policy, error) {
+	return p, nil
+}
+
+type stubGroupResolver []int64
+
+func (r stubGroupResolver) GetGroupIDs(_ context.Context, _, _ int64) ([]int64, error) {
+	return r, nil
+}
+
+// stubGroupDirectory resolves groups per member, and expands them back into members.
+type stubGroupDirectory map[int64][]int64
+
+func (d stubGroupDirectory) GetGroupIDs(_ context.Context, _, teamMemberID int64) ([]int64, error) {
+	var groupIDs []int64
+	for groupID, memberIDs := range d {
+		if slices.Contains(memberIDs, teamMemberID) {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	slices.Sort(groupIDs)
+	return groupIDs, nil
+}
+
+func (d stubGroupDirectory) GetMemberIDs(_ context.Context, _, groupID int64) ([]int64, error) {
+	return d[groupID], nil
+}
+
+// stubRoleGrants hands out grants per member, like roles.Service.
+type stubRoleGrants map[int64][]policies.Policy
+
+func (g stubRoleGrants) GetGrants(_ context.Context, request *policies.GetPolicyRequest) ([]policies.Policy, error) {
+	return g[request.TeamMemberID], nil
+}
+
+func (g stubRoleGrants) GetGrantees(_ context.Context, _ int64) ([]int64, error) {
+	return slices.Collect(maps.Keys(g)), nil
+}
+
+type failingGroupResolver struct{}
+
+func (failingGroupResolver) GetGroupIDs(_ context.Context, _, _ int64) ([]int64, error) {
+	return nil, errors.New("connection refused")
+}
+
 func TestCreatePolicy(t *testing.T) {
 	t.Run("Successfully creates policy when user already has broader policy", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		test := setup(ctrl)
+		test.expectTransaction()
 		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
 			AccountID:    100,
 			TeamMemberID: 200,
-			Action:       policies.ActionRead,
+			Actions:      []policies.Action{policies.ActionRead, policies.ActionWrite},
 		}).Return([]policies.Policy{
 			{
 				ID:           1,
@@ -54,10 +115,11 @@ func TestCreatePolicy(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		test := setup(ctrl)
+		test.expectTransaction()
 		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
 			AccountID:    100,
 			TeamMemberID: 200,
-			Action:       policies.ActionRead,
+			Actions:      []policies.Action{policies.ActionRead, policies.ActionWrite},
 		}).Return([]policies.Policy{
 			{
 				ID:           1,
@@ -67,30 +129,27 @@ func TestCreatePolicy(t *testing.T) {
 				Action:       policies.ActionRead,
 			},
 			{
-				ID:           1,
+				ID:           2,
 				AccountID:    100,
 				TeamMemberID: 200,
 				Resource:     "blogs/123/*",
 				Action:       policies.ActionRead,
 			},
 		}, nil)
-		test.mockRepository.EXPECT().DeleteByPrefix(gomock.Any(), &policies.DeleteByPrefixRequest{
-			AccountID:      100,
-			TeamMemberID:   200,
-			ResourcePrefix: "blogs/",
-			Action:         policies.ActionRead,
-		}).Return(nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "2").Return(nil)
 		test.mockRepository.EXPECT().Create(gomock.Any(), &policies.Policy{
 			AccountID:    100,
 			TeamMemberID: 200,
 			Resource:     "blogs/*",
 			Action:       policies.ActionRead,
+			Effect:       policies.EffectAllow,
 		}).Return(&policies.Policy{
 			ID:           1,
 			AccountID:    100,
 			TeamMemberID: 200,
 			Resource:     "blogs/*",
 			Action:       policies.ActionRead,
+			Effect:       policies.EffectAllow,
 		}, nil)
 		service := policies.NewService(test.mockRepository)
 
@@ -105,153 +164,1473 @@ func TestCreatePolicy(t *testing.T) {
 		assert.NotNil(t, policy)
 		assert.Equal(t, int64(1), policy.ID)
 	})
-}
 
-func TestCheckPermission(t *testing.T) {
-	tests := []struct {
-		name          string
-		request       *policies.CheckPermissionRequest
-		mockPolicies  []policies.Policy
-		mockAction    policies.Action
-		wantPermitted bool
-	}{
-		{
-			name: "permission granted for exact match",
-			request: &policies.CheckPermissionRequest{
-				AccountID:    100,
-				TeamMemberID: 200,
-				Resource:     "projects/123/tasks/456",
-				Action:       policies.ActionRead,
-			},
-			mockAction: policies.ActionRead,
-			mockPolicies: []policies.Policy{
-				{
-					ID:           1,
-					AccountID:    100,
-					TeamMemberID: 200,
-					Resource:     "projects/123/tasks/456",
-					Action:       policies.ActionRead,
-				},
-			},
-			wantPermitted: true,
-		},
-		{
-			name: "permission granted when broader policy exists",
-			request: &policies.CheckPermissionRequest{
-				AccountID:    100,
-				TeamMemberID: 200,
-				Resource:     "projects/123/tasks/456",
-				Action:       policies.ActionRead,
-			},
-			mockAction: policies.ActionRead,
-			mockPolicies: []policies.Policy{
-				{
-					ID:           1,
-					AccountID:    100,
-					TeamMemberID: 200,
-					Resource:     "projects/*",
-					Action:       policies.ActionRead,
-				},
-			},
-			wantPermitted: true,
-		},
-		{
-			name: "permission denied when no matching policy exists",
-			request: &policies.CheckPermissionRequest{
-				AccountID:    100,
-				TeamMemberID: 200,
-				Resource:     "projects/123/tasks/456",
-				Action:       policies.ActionWrite,
-			},
-			mockAction: policies.ActionWrite,
-			mockPolicies: []policies.Policy{
-				{
-					ID:           1,
-					AccountID:    100,
-					TeamMemberID: 200,
-					Resource:     "projects/123",
-					Action:       policies.ActionRead,
-				},
-			},
-			wantPermitted: false,
-		},
-		{
-			name: "permission granted with root policy",
-			request: &policies.CheckPermissionRequest{
-				AccountID:    100,
-				TeamMemberID: 200,
-				Resource:     "projects/999",
-				Action:       policies.ActionRead,
-			},
-			mockAction: policies.ActionRead,
-			mockPolicies: []policies.Policy{
-				{
-					ID:           1,
-					AccountID:    100,
-					TeamMemberID: 200,
-					Resource:     "*",
-					Action:       policies.ActionRead,
-				},
-			},
-			wantPermitted: true,
-		},
-		{
-			name: "read permitted on parent resource when child policy exists",
-			request: &policies.CheckPermissionRequest{
-				AccountID:    100,
-				TeamMemberID: 200,
-				Resource:     "projects/123",
-				Action:       policies.ActionRead,
-			},
-			mockAction: policies.ActionRead,
-			mockPolicies: []policies.Policy{
-				{
-					ID:           1,
-					AccountID:    100,
-					TeamMemberID: 200,
-					Resource:     "projects/123/tasks/456",
-					Action:       policies.ActionRead,
-				},
-			},
-			wantPermitted: true,
-		},
-		{
-			name: "write denied on parent resource when child policy exists",
-			request: &policies.CheckPermissionRequest{
+	t.Run("Successfully creates narrower deny policy when user has broader allow policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Actions:      []policies.Action{policies.ActionWrite},
+		}).Return([]policies.Policy{
+			{
+				ID:           1,
 				AccountID:    100,
 				TeamMemberID: 200,
-				Resource:     "projects/123",
+				Resource:     "blogs/*",
 				Action:       policies.ActionWrite,
+				Effect:       policies.EffectAllow,
 			},
-			mockAction: policies.ActionWrite,
-			mockPolicies: []policies.Policy{
-				{
-					ID:           1,
-					AccountID:    100,
-					TeamMemberID: 200,
-					Resource:     "projects/123/tasks/456",
-					Action:       policies.ActionWrite,
-				},
-			},
-			wantPermitted: false,
-		},
-	}
+		}, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/42/settings",
+			Action:       policies.ActionWrite,
+			Effect:       policies.EffectDeny,
+		}).Return(&policies.Policy{
+			ID:           2,
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/42/settings",
+			Action:       policies.ActionWrite,
+			Effect:       policies.EffectDeny,
+		}, nil)
+		service := policies.NewService(test.mockRepository)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-			test := setup(ctrl)
-			test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
-				AccountID:    tt.request.AccountID,
-				TeamMemberID: tt.request.TeamMemberID,
-				Action:       tt.mockAction,
-			}).Return(tt.mockPolicies, nil)
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/42/settings",
+			Action:       policies.ActionWrite,
+			Effect:       policies.EffectDeny,
+		})
 
-			service := policies.NewService(test.mockRepository)
+		assert.NoError(t, err)
+		assert.NotNil(t, policy)
+		assert.Equal(t, int64(2), policy.ID)
+	})
+
+	t.Run("Rejects policy with invalid effect", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionWrite,
+			Effect:       "maybe",
+		})
+
+		assert.ErrorIs(t, err, policies.ErrInvalidEffect)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Rejects policy targeting both team member and group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
 
-			hasPermission := service.CheckPermission(t.Context(), tt.request)
-			assert.Equal(t, tt.wantPermitted, hasPermission)
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			GroupID:      1,
+			Resource:     "blogs/*",
+			Action:       policies.ActionWrite,
 		})
-	}
+
+		assert.ErrorIs(t, err, policies.ErrInvalidPrincipal)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Successfully creates group policy pruning only that group's policies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+			AccountID: 100,
+			GroupIDs:  []int64{1},
+			Actions:   []policies.Action{policies.ActionWrite},
+		}).Return(nil, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{
+			ID:        3,
+			AccountID: 100,
+			GroupID:   1,
+			Resource:  "blogs/*",
+			Action:    policies.ActionWrite,
+			Effect:    policies.EffectAllow,
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID: 100,
+			GroupID:   1,
+			Resource:  "blogs/*",
+			Action:    policies.ActionWrite,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), policy.ID)
+	})
+
+	t.Run("Rejects policy with unregistered action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionPublish,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrUnknownAction)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Rejects policy on resource matching no registered template", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		registry := policies.NewResourceRegistry()
+		registry.Register(policies.ResourceTemplate{Template: "blogs/{blogID}", Actions: []policies.Action{policies.ActionRead}})
+		service := policies.NewService(test.mockRepository, policies.WithResourceRegistry(registry))
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1/comments/2",
+			Action:       policies.ActionRead,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrUnknownResource)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Successfully creates policy on registered resource template", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{ID: 5}, nil)
+		registry := policies.NewResourceRegistry()
+		registry.Register(policies.ResourceTemplate{Template: "blogs/{blogID}/pages/{pageID}", Actions: []policies.Action{policies.ActionRead}})
+		service := policies.NewService(test.mockRepository, policies.WithResourceRegistry(registry))
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1/*",
+			Action:       policies.ActionRead,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), policy.ID)
+	})
+
+	t.Run("Successfully creates policy with registered action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{ID: 4}, nil)
+		registry := policies.NewActionRegistry()
+		registry.Register("blogs", policies.ActionPublish)
+		service := policies.NewService(test.mockRepository, policies.WithActionRegistry(registry))
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionPublish,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), policy.ID)
+	})
+
+	t.Run("Rejects policy already covered by an implying action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Actions:      []policies.Action{policies.ActionRead, policies.ActionWrite},
+		}).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrUserAlreadyHasBroaderPolicy)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Rejects policy covered by a mid-path wildcard", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "funnels/*/pages/**", Action: policies.ActionWrite},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "funnels/12/pages/3/components/*",
+			Action:       policies.ActionWrite,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrUserAlreadyHasBroaderPolicy)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Successfully creates mid-path wildcard policy deleting only covered policies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/settings", Action: policies.ActionWrite},
+			{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/pages/2", Action: policies.ActionWrite},
+			{ID: 3, AccountID: 100, TeamMemberID: 200, Resource: "blogs/2/settings", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+		}, nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{ID: 4}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*/settings",
+			Action:       policies.ActionWrite,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), policy.ID)
+	})
+
+	t.Run("Successfully creates policy with normalized resource", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionWrite,
+			Effect:       policies.EffectAllow,
+		}).Return(&policies.Policy{ID: 5}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "/blogs//*/",
+			Action:       policies.ActionWrite,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), policy.ID)
+	})
+
+	t.Run("Rejects policy with malformed resource pattern", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/blog*",
+			Action:       policies.ActionWrite,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrInvalidResource)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Rejects policy with unparsable condition", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionWrite,
+			Condition:    "time <",
+		})
+
+		assert.ErrorIs(t, err, policies.ErrInvalidCondition)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Rejects policy expiring before it becomes active", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
+		notBefore := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionWrite,
+			NotBefore:    &notBefore,
+			ExpiresAt:    &expiresAt,
+		})
+
+		assert.ErrorIs(t, err, policies.ErrInvalidValidity)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Successfully creates permanent policy when user has temporary broader policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		expiresAt := time.Now().Add(time.Hour)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite, ExpiresAt: &expiresAt},
+		}, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{ID: 2}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionWrite,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), policy.ID)
+	})
+
+	t.Run("Successfully creates exact policy keeping policies under it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		// blogs/1 does not cover blogs/1/pages/2, so deleting it would revoke write on the page.
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/pages/2", Action: policies.ActionWrite},
+		}, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{ID: 2}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionWrite,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), policy.ID)
+	})
+
+	t.Run("Successfully creates temporary broader policy without deleting narrower policies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.expectTransaction()
+		expiresAt := time.Now().Add(time.Hour)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1", Action: policies.ActionWrite},
+		}, nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&policies.Policy{ID: 2}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionWrite,
+			ExpiresAt:    &expiresAt,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), policy.ID)
+	})
+
+	t.Run("Rolls back deleted narrower policies when create fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		repositoryErr := errors.New("connection refused")
+		var transactionErr error
+		test.mockRepository.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fn func(policies.Repository) error) error {
+				transactionErr = fn(test.mockRepository)
+				return transactionErr
+			})
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1", Action: policies.ActionRead},
+		}, nil)
+		// blogs/1 is deleted within the transaction, then the create fails and the transaction is rolled back.
+		test.mockRepository.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+		test.mockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repositoryErr)
+		service := policies.NewService(test.mockRepository)
+
+		policy, err := service.CreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/*",
+			Action:       policies.ActionRead,
+		})
+
+		assert.ErrorIs(t, err, repositoryErr)
+		assert.ErrorIs(t, transactionErr, repositoryErr)
+		assert.Nil(t, policy)
+	})
+}
+
+func TestCheckPermission(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *policies.CheckPermissionRequest
+		mockPolicies  []policies.Policy
+		mockActions   []policies.Action
+		wantPermitted bool
+	}{
+		{
+			name: "permission granted for exact match",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "projects/123/tasks/456",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "projects/123/tasks/456",
+					Action:       policies.ActionRead,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "permission granted when broader policy exists",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "projects/123/tasks/456",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "projects/*",
+					Action:       policies.ActionRead,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "permission denied when no matching policy exists",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "projects/123/tasks/456",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "projects/123",
+					Action:       policies.ActionRead,
+				},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "permission granted with root policy",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "projects/999",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "*",
+					Action:       policies.ActionRead,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "read permitted on parent resource when child policy exists",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "projects/123",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "projects/123/tasks/456",
+					Action:       policies.ActionRead,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "write denied on parent resource when child policy exists",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "projects/123",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "projects/123/tasks/456",
+					Action:       policies.ActionWrite,
+				},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "deny overrides broader allow",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/42/settings",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/*",
+					Action:       policies.ActionWrite,
+					Effect:       policies.EffectAllow,
+				},
+				{
+					ID:           2,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/42/settings",
+					Action:       policies.ActionWrite,
+					Effect:       policies.EffectDeny,
+				},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "allow still applies outside of denied resource",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/42/pages/1",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/*",
+					Action:       policies.ActionWrite,
+					Effect:       policies.EffectAllow,
+				},
+				{
+					ID:           2,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/42/settings",
+					Action:       policies.ActionWrite,
+					Effect:       policies.EffectDeny,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "permission granted when condition holds",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionWrite,
+				Attributes:   map[string]string{"ip": "10.0.0.7"},
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/*",
+					Action:       policies.ActionWrite,
+					Condition:    `ip in "10.0.0.0/8"`,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "permission denied when condition does not hold",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionWrite,
+				Attributes:   map[string]string{"ip": "192.168.0.7"},
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/*",
+					Action:       policies.ActionWrite,
+					Condition:    `ip in "10.0.0.0/8"`,
+				},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "permission denied by a deny whose condition cannot be evaluated",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/*",
+					Action:       policies.ActionWrite,
+				},
+				{
+					ID:           2,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/1",
+					Action:       policies.ActionWrite,
+					Effect:       policies.EffectDeny,
+					Condition:    `ip in "10.0.0.0/8"`,
+				},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "read granted through implying write policy",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/*",
+					Action:       policies.ActionWrite,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "deny on implying action does not deny implied action",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{
+					ID:           1,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/*",
+					Action:       policies.ActionRead,
+				},
+				{
+					ID:           2,
+					AccountID:    100,
+					TeamMemberID: 200,
+					Resource:     "blogs/1",
+					Action:       policies.ActionWrite,
+					Effect:       policies.EffectDeny,
+				},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "permission granted through mid-path wildcard",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/42/settings",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*/settings", Action: policies.ActionWrite},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "mid-path wildcard matches a single segment only",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/42/pages/1/settings",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*/settings", Action: policies.ActionWrite},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "recursive wildcard matches any depth",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "funnels/7/pages/3/components/9",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "funnels/*/pages/**", Action: policies.ActionWrite},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "recursive wildcard does not match other sub-resources",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "funnels/7/settings",
+				Action:       policies.ActionWrite,
+			},
+			mockActions: []policies.Action{policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "funnels/*/pages/**", Action: policies.ActionWrite},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "read denied on resource sharing a string prefix with child policy",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/11/pages/12", Action: policies.ActionRead},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "stored resource with leading slash is normalized",
+			request: &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1/",
+				Action:       policies.ActionRead,
+			},
+			mockActions: []policies.Action{policies.ActionRead, policies.ActionWrite},
+			mockPolicies: []policies.Policy{
+				{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "/blogs/*", Action: policies.ActionRead},
+			},
+			wantPermitted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			test := setup(ctrl)
+			test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+				AccountID:    tt.request.AccountID,
+				TeamMemberID: tt.request.TeamMemberID,
+				Actions:      tt.mockActions,
+			}).Return(tt.mockPolicies, nil)
+
+			service := policies.NewService(test.mockRepository)
+
+			decision, err := service.CheckPermission(t.Context(), tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPermitted, decision.Allowed)
+		})
+	}
+}
+
+func TestCheckPermissionWithValidityWindow(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		mockPolicies  []policies.Policy
+		wantPermitted bool
+	}{
+		{
+			name: "permission granted within validity window",
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/*", Action: policies.ActionRead, NotBefore: &past, ExpiresAt: &future},
+			},
+			wantPermitted: true,
+		},
+		{
+			name: "permission denied by expired policy",
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/*", Action: policies.ActionRead, ExpiresAt: &past},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "permission denied by policy not yet active",
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/*", Action: policies.ActionRead, NotBefore: &future},
+			},
+			wantPermitted: false,
+		},
+		{
+			name: "expired deny no longer overrides allow",
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/*", Action: policies.ActionRead},
+				{ID: 2, Resource: "blogs/1", Action: policies.ActionRead, Effect: policies.EffectDeny, ExpiresAt: &past},
+			},
+			wantPermitted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			test := setup(ctrl)
+			test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(tt.mockPolicies, nil)
+			service := policies.NewService(test.mockRepository)
+
+			decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       policies.ActionRead,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPermitted, decision.Allowed)
+		})
+	}
+}
+
+func TestCheckPermissionWithGrantProvider(t *testing.T) {
+	t.Run("permission granted through role-derived grant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+		service := policies.NewService(test.mockRepository, policies.WithGrantProvider(stubGrantProvider{
+			{RoleID: 1, Resource: "blogs/*", Action: policies.ActionWrite},
+		}))
+
+		decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionWrite,
+		})
+		assert.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	})
+
+	t.Run("direct deny overrides role-derived grant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, Resource: "blogs/1", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+		}, nil)
+		service := policies.NewService(test.mockRepository, policies.WithGrantProvider(stubGrantProvider{
+			{RoleID: 1, Resource: "blogs/*", Action: policies.ActionWrite},
+		}))
+
+		decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionWrite,
+		})
+		assert.NoError(t, err)
+		assert.False(t, decision.Allowed)
+	})
+}
+
+func TestCheckPermissionWithGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	test := setup(ctrl)
+	test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+		GroupIDs:     []int64{1, 2},
+		Actions:      []policies.Action{policies.ActionWrite},
+	}).Return([]policies.Policy{
+		{ID: 1, AccountID: 100, GroupID: 2, Resource: "funnels/*", Action: policies.ActionWrite},
+	}, nil)
+	service := policies.NewService(test.mockRepository, policies.WithGroupResolver(stubGroupResolver{1, 2}))
+
+	decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+		Resource:     "funnels/12",
+		Action:       policies.ActionWrite,
+	})
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestCheckPermissionDecision(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		action       policies.Action
+		mockPolicies []policies.Policy
+		want         *policies.Decision
+	}{
+		{
+			name:   "exact match",
+			action: policies.ActionRead,
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/1", Action: policies.ActionRead},
+			},
+			want: &policies.Decision{Allowed: true, PolicyIDs: []int64{1}, Rule: policies.MatchRuleExact},
+		},
+		{
+			name:   "every matching allow is listed",
+			action: policies.ActionRead,
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/*", Action: policies.ActionRead},
+				{ID: 2, Resource: "*", Action: policies.ActionRead},
+				{RoleID: 7, Resource: "blogs/1", Action: policies.ActionRead},
+			},
+			want: &policies.Decision{Allowed: true, PolicyIDs: []int64{1, 2}, RoleIDs: []int64{7}, Rule: policies.MatchRuleWildcard},
+		},
+		{
+			name:   "parent read",
+			action: policies.ActionRead,
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/1/pages/2", Action: policies.ActionRead},
+			},
+			want: &policies.Decision{Allowed: true, PolicyIDs: []int64{1}, Rule: policies.MatchRuleParentRead},
+		},
+		{
+			name:   "explicit deny names only the deny",
+			action: policies.ActionRead,
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/*", Action: policies.ActionRead},
+				{ID: 2, Resource: "*", Action: policies.ActionRead, Effect: policies.EffectDeny},
+			},
+			want: &policies.Decision{PolicyIDs: []int64{2}, Rule: policies.MatchRuleRoot, Reason: policies.DenialReasonExplicitDeny},
+		},
+		{
+			name:   "no matching policy",
+			action: policies.ActionWrite,
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/1/pages/2", Action: policies.ActionWrite},
+			},
+			want: &policies.Decision{Reason: policies.DenialReasonNoMatchingPolicy},
+		},
+		{
+			name:   "matching allow whose condition does not hold",
+			action: policies.ActionRead,
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/1", Action: policies.ActionRead, Condition: `ip in "10.0.0.0/8"`},
+			},
+			want: &policies.Decision{Reason: policies.DenialReasonConditionNotMet},
+		},
+		{
+			name:   "matching allow that expired",
+			action: policies.ActionRead,
+			mockPolicies: []policies.Policy{
+				{ID: 1, Resource: "blogs/1", Action: policies.ActionRead, ExpiresAt: &past},
+			},
+			want: &policies.Decision{Reason: policies.DenialReasonPolicyInactive},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			test := setup(ctrl)
+			test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(tt.mockPolicies, nil)
+			service := policies.NewService(test.mockRepository)
+
+			decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+				AccountID:    100,
+				TeamMemberID: 200,
+				Resource:     "blogs/1",
+				Action:       tt.action,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, decision)
+		})
+	}
+
+	t.Run("invalid resource", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
+
+		decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{Resource: "blogs/a*"})
+		assert.NoError(t, err)
+		assert.Equal(t, &policies.Decision{Reason: policies.DenialReasonInvalidResource}, decision)
+	})
+}
+
+func TestCheckPermissionFailure(t *testing.T) {
+	t.Run("fails closed with typed error when policies cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		dbErr := errors.New("connection refused")
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+		service := policies.NewService(test.mockRepository)
+
+		decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+		})
+		assert.ErrorIs(t, err, policies.ErrPermissionCheckUnavailable)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, &policies.Decision{Reason: policies.DenialReasonLookupFailed}, decision)
+	})
+
+	t.Run("fails closed with typed error when groups cannot be resolved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository, policies.WithGroupResolver(failingGroupResolver{}))
+
+		decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+		})
+		assert.ErrorIs(t, err, policies.ErrPermissionCheckUnavailable)
+		assert.False(t, decision.Allowed)
+	})
+}
+
+func TestCheckPermissions(t *testing.T) {
+	t.Run("loads policies once per action and keeps request order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Actions:      []policies.Action{policies.ActionWrite},
+		}).Return([]policies.Policy{
+			{ID: 1, Resource: "blogs/1/pages/*", Action: policies.ActionWrite},
+		}, nil).Times(1)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Actions:      []policies.Action{policies.ActionRead, policies.ActionWrite},
+		}).Return([]policies.Policy{
+			{ID: 1, Resource: "blogs/1/pages/*", Action: policies.ActionWrite},
+		}, nil).Times(1)
+		service := policies.NewService(test.mockRepository)
+
+		decisions, err := service.CheckPermissions(t.Context(), &policies.CheckPermissionsRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Items: []policies.PermissionCheckItem{
+				{Resource: "blogs/1/pages/1", Action: policies.ActionWrite},
+				{Resource: "blogs/2/pages/1", Action: policies.ActionWrite},
+				{Resource: "blogs/a*", Action: policies.ActionWrite},
+				{Resource: "blogs/1/pages/2", Action: policies.ActionRead},
+				{Resource: "blogs/1/pages/3", Action: policies.ActionWrite},
+			},
+		})
+
+		assert.NoError(t, err)
+		allowed := make([]bool, 0, len(decisions))
+		for _, decision := range decisions {
+			allowed = append(allowed, decision.Allowed)
+		}
+		assert.Equal(t, []bool{true, false, false, true, true}, allowed)
+		assert.Equal(t, policies.DenialReasonInvalidResource, decisions[2].Reason)
+	})
+
+	t.Run("fails the whole batch when policies cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+		service := policies.NewService(test.mockRepository)
+
+		decisions, err := service.CheckPermissions(t.Context(), &policies.CheckPermissionsRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Items: []policies.PermissionCheckItem{
+				{Resource: "blogs/1", Action: policies.ActionRead},
+			},
+		})
+
+		assert.ErrorIs(t, err, policies.ErrPermissionCheckUnavailable)
+		assert.Nil(t, decisions)
+	})
+}
+
+func TestListAccessibleResources(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("lists write patterns under prefix with implying actions and carve-outs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Actions:      []policies.Action{policies.ActionWrite},
+		}).Return([]policies.Policy{
+			{ID: 1, Resource: "funnels/12", Action: policies.ActionWrite},
+			{ID: 2, Resource: "funnels/*/pages/*", Action: policies.ActionWrite},
+			{ID: 3, Resource: "blogs/*", Action: policies.ActionWrite},
+			{ID: 4, Resource: "funnels/13", Action: policies.ActionWrite, ExpiresAt: &past},
+			{ID: 5, Resource: "funnels/12/pages/1", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+		}, nil)
+		service := policies.NewService(test.mockRepository, policies.WithGrantProvider(stubGrantProvider{
+			{RoleID: 7, Resource: "funnels/12", Action: policies.ActionWrite},
+		}))
+
+		resources, err := service.ListAccessibleResources(t.Context(), &policies.ListAccessibleResourcesRequest{
+			AccountID:      100,
+			TeamMemberID:   200,
+			Action:         policies.ActionWrite,
+			ResourcePrefix: "funnels",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &policies.AccessibleResources{
+			Allowed: []policies.AccessibleResource{
+				{Resource: "funnels/*/pages/*", Rule: policies.MatchRuleWildcard, PolicyIDs: []int64{2}},
+				{Resource: "funnels/12", Rule: policies.MatchRuleExact, PolicyIDs: []int64{1}, RoleIDs: []int64{7}},
+			},
+			Denied: []policies.AccessibleResource{
+				{Resource: "funnels/12/pages/1", Rule: policies.MatchRuleExact, PolicyIDs: []int64{5}},
+			},
+		}, resources)
+	})
+
+	t.Run("reflects root access and parent read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, Resource: "blogs/11/pages/12", Action: policies.ActionRead},
+			{ID: 2, Resource: "blogs/11", Action: policies.ActionWrite},
+			{ID: 3, Resource: "*", Action: policies.ActionRead, Condition: `ip in "10.0.0.0/8"`},
+			{ID: 4, Resource: "funnels/1/pages/2", Action: policies.ActionRead},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		resources, err := service.ListAccessibleResources(t.Context(), &policies.ListAccessibleResourcesRequest{
+			AccountID:      100,
+			TeamMemberID:   200,
+			Action:         policies.ActionRead,
+			ResourcePrefix: "blogs/11",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []policies.AccessibleResource{
+			{Resource: "*", Rule: policies.MatchRuleRoot, Condition: `ip in "10.0.0.0/8"`, PolicyIDs: []int64{3}},
+			{Resource: "blogs/11", Rule: policies.MatchRuleExact, PolicyIDs: []int64{2, 1}},
+			{Resource: "blogs/11/pages", Rule: policies.MatchRuleParentRead, PolicyIDs: []int64{1}},
+			{Resource: "blogs/11/pages/12", Rule: policies.MatchRuleExact, PolicyIDs: []int64{1}},
+		}, resources.Allowed)
+		assert.Empty(t, resources.Denied)
+	})
+}
+
+func TestListAuthorizedMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	test := setup(ctrl)
+	test.mockRepository.EXPECT().GetByResource(gomock.Any(), &policies.GetByResourceRequest{
+		AccountID: 100,
+		Resource:  "blogs/12/settings",
+		Actions:   []policies.Action{policies.ActionWrite},
+	}).Return([]policies.Policy{
+		{ID: 1, TeamMemberID: 201, Resource: "blogs/*", Action: policies.ActionWrite},
+		{ID: 2, TeamMemberID: 202, Resource: "blogs/*", Action: policies.ActionWrite},
+		{ID: 3, GroupID: 10, Resource: "blogs/*/settings", Action: policies.ActionWrite},
+		{ID: 4, TeamMemberID: 205, Resource: "blogs/*/pages", Action: policies.ActionWrite},
+	}, nil)
+	// Member policies as seen by CheckPermission; 202 is carved out by a deny.
+	memberPolicies := map[int64][]policies.Policy{
+		201: {{ID: 1, TeamMemberID: 201, Resource: "blogs/*", Action: policies.ActionWrite}},
+		202: {
+			{ID: 2, TeamMemberID: 202, Resource: "blogs/*", Action: policies.ActionWrite},
+			{ID: 5, TeamMemberID: 202, Resource: "blogs/12/*", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+		},
+		203: {{ID: 3, GroupID: 10, Resource: "blogs/*/settings", Action: policies.ActionWrite}},
+	}
+	test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *policies.GetPolicyRequest) ([]policies.Policy, error) {
+			return memberPolicies[request.TeamMemberID], nil
+		}).AnyTimes()
+	service := policies.NewService(test.mockRepository,
+		policies.WithGroupResolver(stubGroupDirectory{10: {203}}),
+		policies.WithGrantProvider(stubRoleGrants{
+			204: {{RoleID: 7, TeamMemberID: 204, Resource: "*", Action: policies.ActionWrite}},
+			206: {{RoleID: 8, TeamMemberID: 206, Resource: "funnels/*", Action: policies.ActionWrite}},
+		}),
+	)
+
+	members, err := service.ListAuthorizedMembers(t.Context(), &policies.ListAuthorizedMembersRequest{
+		AccountID: 100,
+		Resource:  "blogs/12/settings",
+		Action:    policies.ActionWrite,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []policies.AuthorizedMember{
+		{TeamMemberID: 201, Decision: &policies.Decision{Allowed: true, PolicyIDs: []int64{1}, Rule: policies.MatchRuleWildcard}},
+		{TeamMemberID: 203, Decision: &policies.Decision{Allowed: true, PolicyIDs: []int64{3}, Rule: policies.MatchRuleWildcard}},
+		{TeamMemberID: 204, Decision: &policies.Decision{Allowed: true, RoleIDs: []int64{7}, Rule: policies.MatchRuleRoot}},
+	}, members)
+}
+
+func TestGetEffectivePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	test := setup(ctrl)
+	expiresAt := time.Now().Add(time.Hour)
+	test.mockRepository.EXPECT().Get(gomock.Any(), &policies.GetPolicyRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+		GroupIDs:     []int64{10},
+		Actions:      []policies.Action{policies.ActionRead, policies.ActionWrite},
+	}).Return([]policies.Policy{
+		{ID: 1, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite},
+		{ID: 2, TeamMemberID: 200, Resource: "blogs/1", Action: policies.ActionRead},
+		{ID: 3, GroupID: 10, Resource: "blogs/*", Action: policies.ActionRead},
+		{ID: 4, TeamMemberID: 200, Resource: "funnels/1", Action: policies.ActionRead, Condition: `ip in "10.0.0.0/8"`},
+		{ID: 5, TeamMemberID: 200, Resource: "funnels/*", Action: policies.ActionRead, ExpiresAt: &expiresAt},
+		{ID: 6, TeamMemberID: 200, Resource: "blogs/1/settings", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+	}, nil)
+	service := policies.NewService(test.mockRepository,
+		policies.WithGroupResolver(stubGroupResolver{10}),
+		policies.WithGrantProvider(stubGrantProvider{
+			{RoleID: 7, TeamMemberID: 200, Resource: "blogs/2", Action: policies.ActionWrite},
+		}),
+	)
+
+	permissions, err := service.GetEffectivePermissions(t.Context(), &policies.GetEffectivePermissionsRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []policies.ActionPermissions{
+		{
+			Action: policies.ActionRead,
+			Allowed: []policies.EffectiveGrant{
+				{Resource: "blogs/*", Sources: []policies.GrantSource{
+					{PolicyID: 1, Action: policies.ActionWrite},
+					{PolicyID: 3, GroupID: 10, Action: policies.ActionRead},
+				}},
+				// A temporary or conditional grant never collapses another grant.
+				{Resource: "funnels/*", ExpiresAt: &expiresAt, Sources: []policies.GrantSource{{PolicyID: 5, Action: policies.ActionRead}}},
+				{Resource: "funnels/1", Condition: `ip in "10.0.0.0/8"`, Sources: []policies.GrantSource{{PolicyID: 4, Action: policies.ActionRead}}},
+			},
+		},
+		{
+			Action: policies.ActionWrite,
+			Allowed: []policies.EffectiveGrant{
+				{Resource: "blogs/*", Sources: []policies.GrantSource{{PolicyID: 1, Action: policies.ActionWrite}}},
+			},
+			Denied: []policies.EffectiveGrant{
+				{Resource: "blogs/1/settings", Sources: []policies.GrantSource{{PolicyID: 6, Action: policies.ActionWrite}}},
+			},
+		},
+	}, permissions)
+}
+
+func TestCheckPermissionBuiltInAttributes(t *testing.T) {
+	t.Run("client supplied time does not dodge a conditional deny", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		// The deny applies at any time but the spoofed one, which is half a day away from the server clock.
+		spoofed := time.Now().Add(12 * time.Hour).Format("15:04")
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite},
+			{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1", Action: policies.ActionWrite, Effect: policies.EffectDeny, Condition: "time != " + spoofed},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		decision, err := service.CheckPermission(t.Context(), &policies.CheckPermissionRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionWrite,
+			Attributes:   map[string]string{policies.AttributeTime: spoofed},
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, []int64{2}, decision.PolicyIDs)
+	})
+}
+
+func TestDryRunCreatePolicy(t *testing.T) {
+	t.Run("reports deleted narrower policies without writing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		// No Create or Delete expectations: any write fails the test.
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/*", Action: policies.ActionRead},
+			{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "blogs/2", Action: policies.ActionRead, Effect: policies.EffectDeny},
+			{ID: 3, AccountID: 100, TeamMemberID: 200, Resource: "blogs/3", Action: policies.ActionWrite},
+			{ID: 4, AccountID: 100, TeamMemberID: 200, Resource: "funnels/1", Action: policies.ActionRead},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		plan, err := service.DryRunCreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "/blogs/*",
+			Action:       policies.ActionRead,
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, plan.IsRedundant)
+		assert.Equal(t, "blogs/*", plan.Policy.Resource)
+		assert.Equal(t, []int64{1}, plan.DeletedPolicyIDs)
+		var resultingIDs []int64
+		for _, policy := range plan.ResultingPolicies {
+			resultingIDs = append(resultingIDs, policy.ID)
+		}
+		assert.Equal(t, []int64{2, 3, 4, 0}, resultingIDs)
+	})
+
+	t.Run("keeps policies an exact resource does not cover", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/pages/2", Action: policies.ActionWrite},
+		}, nil)
+		service := policies.NewService(test.mockRepository)
+
+		plan, err := service.DryRunCreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionWrite,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, plan.DeletedPolicyIDs)
+		assert.Len(t, plan.ResultingPolicies, 2)
+	})
+
+	t.Run("reports redundant policy and the broader policy covering it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		currentPolicies := []policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite},
+		}
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(currentPolicies, nil)
+		service := policies.NewService(test.mockRepository)
+
+		plan, err := service.DryRunCreatePolicy(t.Context(), &policies.Policy{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Resource:     "blogs/1",
+			Action:       policies.ActionRead,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, plan.IsRedundant)
+		assert.Equal(t, int64(1), plan.BroaderPolicyID)
+		assert.Empty(t, plan.DeletedPolicyIDs)
+		assert.Equal(t, currentPolicies, plan.ResultingPolicies)
+	})
 }
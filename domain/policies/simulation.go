@@ -0,0 +1,96 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var errSimulationReadOnly = errors.New("simulation must not write policies")
+
+// SimulationRequest describes a what-if: Checks run against the stored policies,
+// with Add and RemovePolicyIDs applied in memory only.
+type SimulationRequest struct {
+	// Add holds hypothetical policies. They get negative IDs in decisions, in order: -1, -2, ...
+	Add             []Policy
+	RemovePolicyIDs []int64
+	Checks          []CheckPermissionRequest
+}
+
+func (s *service) Simulate(ctx context.Context, request *SimulationRequest) ([]*Decision, error) {
+	added := make([]Policy, 0, len(request.Add))
+	for i, policy := range request.Add {
+		if _, err := s.validatePolicy(&policy); err != nil {
+			return nil, fmt.Errorf("simulated policy %d: %w", i, err)
+		}
+		policy.ID = -int64(i + 1)
+		added = append(added, policy)
+	}
+
+	// Evaluate with the exact same service, only the storage differs.
+	simulation := *s
+	simulation.repo = &simulatedRepository{
+		Repository: s.repo,
+		added:      added,
+		removedIDs: request.RemovePolicyIDs,
+	}
+
+	decisions := make([]*Decision, 0, len(request.Checks))
+	for i := range request.Checks {
+		decision, err := simulation.CheckPermission(ctx, &request.Checks[i])
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
+
+// simulatedRepository overlays policy deltas on the stored policies and refuses every write.
+type simulatedRepository struct {
+	Repository
+	added      []Policy
+	removedIDs []int64
+}
+
+func (r *simulatedRepository) Get(ctx context.Context, request *GetPolicyRequest) ([]Policy, error) {
+	stored, err := r.Repository.Get(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := slices.DeleteFunc(stored, func(policy Policy) bool {
+		return slices.Contains(r.removedIDs, policy.ID)
+	})
+	for _, policy := range r.added {
+		if request.matches(policy) {
+			policies = append(policies, policy)
+		}
+	}
+
+	return policies, nil
+}
+
+func (r *simulatedRepository) Create(_ context.Context, _ *Policy) (*Policy, error) {
+	return nil, errSimulationReadOnly
+}
+
+func (r *simulatedRepository) Delete(_ context.Context, _ string) error {
+	return errSimulationReadOnly
+}
+
+// Transaction keeps the overlay, so writes within it are refused too.
+func (r *simulatedRepository) Transaction(_ context.Context, fn func(repo Repository) error) error {
+	return fn(r)
+}
+
+func (r *simulatedRepository) DeleteExpired(_ context.Context, _ time.Time) (int64, error) {
+	return 0, errSimulationReadOnly
+}
+
+func (r *simulatedRepository) UpdateResource(_ context.Context, _ int64, _ string) error {
+	return errSimulationReadOnly
+}
@@ -0,0 +1,60 @@
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Run("applies deltas in memory without writing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		// No Create or Delete expectations: any write fails the test.
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]policies.Policy{
+			{ID: 1, AccountID: 100, TeamMemberID: 200, Resource: "blogs/*", Action: policies.ActionWrite},
+			{ID: 2, AccountID: 100, TeamMemberID: 200, Resource: "funnels/*", Action: policies.ActionWrite},
+		}, nil).Times(3)
+		service := policies.NewService(test.mockRepository, policies.WithGroupResolver(stubGroupResolver{10}))
+
+		decisions, err := service.Simulate(t.Context(), &policies.SimulationRequest{
+			Add: []policies.Policy{
+				{AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/settings", Action: policies.ActionWrite, Effect: policies.EffectDeny},
+				{AccountID: 100, GroupID: 10, Resource: "/pages/*", Action: policies.ActionWrite},
+				{AccountID: 100, TeamMemberID: 300, Resource: "pages/*", Action: policies.ActionWrite},
+			},
+			RemovePolicyIDs: []int64{2},
+			Checks: []policies.CheckPermissionRequest{
+				{AccountID: 100, TeamMemberID: 200, Resource: "blogs/1/settings", Action: policies.ActionWrite},
+				{AccountID: 100, TeamMemberID: 200, Resource: "funnels/1", Action: policies.ActionWrite},
+				{AccountID: 100, TeamMemberID: 200, Resource: "pages/1", Action: policies.ActionWrite},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*policies.Decision{
+			{PolicyIDs: []int64{-1}, Rule: policies.MatchRuleExact, Reason: policies.DenialReasonExplicitDeny},
+			{Reason: policies.DenialReasonNoMatchingPolicy},
+			{Allowed: true, PolicyIDs: []int64{-2}, Rule: policies.MatchRuleWildcard},
+		}, decisions)
+	})
+
+	t.Run("rejects invalid simulated policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := policies.NewService(test.mockRepository)
+
+		decisions, err := service.Simulate(t.Context(), &policies.SimulationRequest{
+			Add: []policies.Policy{
+				{AccountID: 100, TeamMemberID: 200, GroupID: 10, Resource: "blogs/*", Action: policies.ActionRead},
+			},
+		})
+
+		assert.ErrorIs(t, err, policies.ErrInvalidPrincipal)
+		assert.Nil(t, decisions)
+	})
+}
@@ -0,0 +1,51 @@
+package policies
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Sweeper periodically deletes expired policies. CheckPermission already ignores
+// them, so sweeping only keeps the table small.
+type Sweeper struct {
+	repo     Repository
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewSweeper(repo Repository, interval time.Duration) *Sweeper {
+	return &Sweeper{repo: repo, interval: interval, now: time.Now}
+}
+
+// Run sweeps once immediately and then on every interval until ctx is done.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Sweeper) Sweep(ctx context.Context) {
+	if ActorFromContext(ctx) == "" {
+		ctx = WithActor(ctx, ActorSweeper)
+	}
+
+	deleted, err := s.repo.DeleteExpired(ctx, s.now())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete expired policies", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		slog.InfoContext(ctx, "deleted expired policies", "count", deleted)
+	}
+}
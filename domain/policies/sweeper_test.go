@@ -0,0 +1,36 @@
+package policies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSweeper(t *testing.T) {
+	t.Run("deletes policies expired before now", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		before := time.Now()
+		test.mockRepository.EXPECT().DeleteExpired(gomock.Cond(func(ctx context.Context) bool {
+			return policies.ActorFromContext(ctx) == policies.ActorSweeper
+		}), gomock.Cond(func(at time.Time) bool {
+			return !at.Before(before)
+		})).Return(int64(2), nil)
+
+		policies.NewSweeper(test.mockRepository, time.Minute).Sweep(t.Context())
+	})
+
+	t.Run("keeps running when the repository fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("connection refused"))
+
+		policies.NewSweeper(test.mockRepository, time.Minute).Sweep(t.Context())
+	})
+}
@@ -0,0 +1,31 @@
+package roles
+
+import "github.com/adhikag24/policy-based-permission-model/domain/policies"
+
+// Role is a named, reusable bundle of resource/action grants within an account.
+// A role inherits every statement of its parent roles. E.g., Editor includes Viewer.
+type Role struct {
+	ID            int64
+	AccountID     int64
+	Name          string
+	Statements    []Statement
+	ParentRoleIDs []int64
+}
+
+type Statement struct {
+	Resource string
+	Action   policies.Action
+	Effect   policies.Effect // Empty effect is treated as allow.
+}
+
+type Assignment struct {
+	AccountID    int64
+	RoleID       int64
+	TeamMemberID int64
+}
+
+// EffectiveStatement is a statement of a flattened role, along with the role that declares it.
+type EffectiveStatement struct {
+	Statement
+	RoleID int64
+}
@@ -0,0 +1,11 @@
+package roles
+
+import "errors"
+
+var (
+	ErrRoleNotFound     = errors.New("role not found")
+	ErrRoleNameRequired = errors.New("role name is required")
+	ErrInvalidStatement = errors.New("invalid role statement; resource and action are required and effect must be allow or deny")
+	ErrInheritanceCycle = errors.New("role inheritance cycle detected")
+	ErrNotAccountMember = errors.New("team member does not belong to the account")
+)
@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/roles/repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/roles/repository.go -destination=domain/roles/mocks/mock_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	roles "github.com/adhikag24/policy-based-permission-model/domain/roles"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockRepository) Assign(ctx context.Context, assignment *roles.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockRepositoryMockRecorder) Assign(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockRepository)(nil).Assign), ctx, assignment)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, role *roles.Role) (*roles.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, role)
+	ret0, _ := ret[0].(*roles.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, role)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, request *roles.GetRoleRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, request)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(ctx context.Context, request *roles.GetRoleRequest) (*roles.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, request)
+	ret0, _ := ret[0].(*roles.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, request)
+}
+
+// GetAssignedTeamMemberIDs mocks base method.
+func (m *MockRepository) GetAssignedTeamMemberIDs(ctx context.Context, accountID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignedTeamMemberIDs", ctx, accountID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignedTeamMemberIDs indicates an expected call of GetAssignedTeamMemberIDs.
+func (mr *MockRepositoryMockRecorder) GetAssignedTeamMemberIDs(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignedTeamMemberIDs", reflect.TypeOf((*MockRepository)(nil).GetAssignedTeamMemberIDs), ctx, accountID)
+}
+
+// GetByTeamMember mocks base method.
+func (m *MockRepository) GetByTeamMember(ctx context.Context, request *roles.GetByTeamMemberRequest) ([]roles.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTeamMember", ctx, request)
+	ret0, _ := ret[0].([]roles.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTeamMember indicates an expected call of GetByTeamMember.
+func (mr *MockRepositoryMockRecorder) GetByTeamMember(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTeamMember", reflect.TypeOf((*MockRepository)(nil).GetByTeamMember), ctx, request)
+}
+
+// IsAccountMember mocks base method.
+func (m *MockRepository) IsAccountMember(ctx context.Context, request *roles.GetByTeamMemberRequest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccountMember", ctx, request)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccountMember indicates an expected call of IsAccountMember.
+func (mr *MockRepositoryMockRecorder) IsAccountMember(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccountMember", reflect.TypeOf((*MockRepository)(nil).IsAccountMember), ctx, request)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, accountID int64) ([]roles.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, accountID)
+	ret0, _ := ret[0].([]roles.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, accountID)
+}
+
+// Unassign mocks base method.
+func (m *MockRepository) Unassign(ctx context.Context, assignment *roles.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unassign", ctx, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unassign indicates an expected call of Unassign.
+func (mr *MockRepositoryMockRecorder) Unassign(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unassign", reflect.TypeOf((*MockRepository)(nil).Unassign), ctx, assignment)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, role *roles.Role) (*roles.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, role)
+	ret0, _ := ret[0].(*roles.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, role)
+}
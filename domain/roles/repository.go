@@ -0,0 +1,32 @@
+package roles
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, role *Role) (*Role, error)
+	// Update replaces the role name, its statements, and its parent roles.
+	Update(ctx context.Context, role *Role) (*Role, error)
+	Delete(ctx context.Context, request *GetRoleRequest) error
+	Get(ctx context.Context, request *GetRoleRequest) (*Role, error)
+	// List returns every role of the account, including statements and parent roles.
+	List(ctx context.Context, accountID int64) ([]Role, error)
+	Assign(ctx context.Context, assignment *Assignment) error
+	Unassign(ctx context.Context, assignment *Assignment) error
+	GetByTeamMember(ctx context.Context, request *GetByTeamMemberRequest) ([]Role, error)
+	// GetAssignedTeamMemberIDs returns every team member of the account with at least one role.
+	GetAssignedTeamMemberIDs(ctx context.Context, accountID int64) ([]int64, error)
+	// IsAccountMember checks the team member against account_team_members.
+	IsAccountMember(ctx context.Context, request *GetByTeamMemberRequest) (bool, error)
+}
+
+// Retreive a single role scoped to its account.
+type GetRoleRequest struct {
+	AccountID int64
+	RoleID    int64
+}
+
+// Retreive roles, with their statements, assigned to a team member.
+type GetByTeamMemberRequest struct {
+	AccountID    int64
+	TeamMemberID int64
+}
@@ -0,0 +1,316 @@
+package roles
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+)
+
+type Service interface {
+	CreateRole(ctx context.Context, role *Role) (*Role, error)
+	UpdateRole(ctx context.Context, role *Role) (*Role, error)
+	DeleteRole(ctx context.Context, request *GetRoleRequest) error
+	GetRole(ctx context.Context, request *GetRoleRequest) (*Role, error)
+	ListRoles(ctx context.Context, accountID int64) ([]Role, error)
+	AssignRole(ctx context.Context, assignment *Assignment) error
+	UnassignRole(ctx context.Context, assignment *Assignment) error
+	// FlattenRole returns the statements of the role and every role it inherits from.
+	FlattenRole(ctx context.Context, request *GetRoleRequest) ([]EffectiveStatement, error)
+
+	// GetGrants returns role-derived grants so policies.Service can evaluate them with direct policies.
+	GetGrants(ctx context.Context, request *policies.GetPolicyRequest) ([]policies.Policy, error)
+	// GetGrantees lets policies.Service find team members who may hold role-derived grants.
+	GetGrantees(ctx context.Context, accountID int64) ([]int64, error)
+}
+
+type Option func(*service)
+
+// WithActionRegistry replaces the default registry, which only knows read and write.
+// Pass the registry of policies.Service so statements accept the same actions as policies.
+func WithActionRegistry(registry *policies.ActionRegistry) Option {
+	return func(s *service) {
+		s.actions = registry
+	}
+}
+
+// WithResourceRegistry rejects statements on resources that match no registered template.
+func WithResourceRegistry(registry *policies.ResourceRegistry) Option {
+	return func(s *service) {
+		s.resources = registry
+	}
+}
+
+type service struct {
+	repo      Repository
+	actions   *policies.ActionRegistry
+	resources *policies.ResourceRegistry
+}
+
+func NewService(repo Repository, options ...Option) Service {
+	s := &service{repo: repo, actions: policies.NewActionRegistry()}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *service) CreateRole(ctx context.Context, role *Role) (*Role, error) {
+	if err := s.validateRole(role); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateInheritance(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, role)
+}
+
+func (s *service) UpdateRole(ctx context.Context, role *Role) (*Role, error) {
+	if err := s.validateRole(role); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateInheritance(ctx, role); err != nil {
+		return nil, err
+	}
+
+	// Grants are resolved from the role at check time, so every holder sees the change immediately.
+	return s.repo.Update(ctx, role)
+}
+
+func (s *service) validateRole(role *Role) error {
+	if role.Name == "" {
+		return ErrRoleNameRequired
+	}
+
+	for i, statement := range role.Statements {
+		if statement.Resource == "" || statement.Action == "" {
+			return ErrInvalidStatement
+		}
+
+		switch statement.Effect {
+		case "":
+			role.Statements[i].Effect = policies.EffectAllow
+		case policies.EffectAllow, policies.EffectDeny:
+		default:
+			return ErrInvalidStatement
+		}
+
+		// Statements are evaluated like policies, so store them in the same canonical form.
+		resource, err := policies.ValidateGrant(statement.Resource, statement.Action, s.actions, s.resources)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+		}
+		role.Statements[i].Resource = resource.String()
+	}
+
+	return nil
+}
+
+// validateInheritance rejects unknown parent roles and inheritance cycles.
+// E.g., Admin -> Editor -> Viewer is valid, but Viewer -> Admin on top of it is not.
+func (s *service) validateInheritance(ctx context.Context, role *Role) error {
+	if len(role.ParentRoleIDs) == 0 {
+		return nil
+	}
+
+	rolesByID, err := s.getRolesByID(ctx, role.AccountID)
+	if err != nil {
+		return err
+	}
+
+	for _, parentRoleID := range role.ParentRoleIDs {
+		if _, ok := rolesByID[parentRoleID]; !ok {
+			return ErrRoleNotFound
+		}
+	}
+
+	// Apply the proposed parents, then walk up from them looking for the role itself.
+	rolesByID[role.ID] = *role
+	visited := make(map[int64]bool)
+	stack := append([]int64{}, role.ParentRoleIDs...)
+	for len(stack) > 0 {
+		roleID := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if roleID == role.ID {
+			return ErrInheritanceCycle
+		}
+		if visited[roleID] {
+			continue
+		}
+		visited[roleID] = true
+		stack = append(stack, rolesByID[roleID].ParentRoleIDs...)
+	}
+
+	return nil
+}
+
+func (s *service) getRolesByID(ctx context.Context, accountID int64) (map[int64]Role, error) {
+	accountRoles, err := s.repo.List(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	rolesByID := make(map[int64]Role, len(accountRoles))
+	for _, role := range accountRoles {
+		rolesByID[role.ID] = role
+	}
+
+	return rolesByID, nil
+}
+
+// resolveInheritance returns the given roles and every role they inherit from, each once.
+// Parents are loaded at call time so changes to a parent role apply immediately.
+func (s *service) resolveInheritance(ctx context.Context, accountID int64, roots []Role) ([]Role, error) {
+	hasParents := false
+	for _, role := range roots {
+		if len(role.ParentRoleIDs) > 0 {
+			hasParents = true
+			break
+		}
+	}
+	if !hasParents {
+		return roots, nil
+	}
+
+	rolesByID, err := s.getRolesByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved []Role
+	visited := make(map[int64]bool)
+	queue := append([]Role{}, roots...)
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+
+		if visited[role.ID] {
+			continue
+		}
+		visited[role.ID] = true
+		resolved = append(resolved, role)
+
+		for _, parentRoleID := range role.ParentRoleIDs {
+			if parent, ok := rolesByID[parentRoleID]; ok {
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+func (s *service) FlattenRole(ctx context.Context, request *GetRoleRequest) ([]EffectiveStatement, error) {
+	role, err := s.repo.Get(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.resolveInheritance(ctx, request.AccountID, []Role{*role})
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []EffectiveStatement
+	seen := make(map[Statement]bool)
+	for _, resolvedRole := range resolved {
+		for _, statement := range resolvedRole.Statements {
+			if seen[statement] {
+				continue
+			}
+			seen[statement] = true
+			statements = append(statements, EffectiveStatement{Statement: statement, RoleID: resolvedRole.ID})
+		}
+	}
+
+	return statements, nil
+}
+
+func (s *service) DeleteRole(ctx context.Context, request *GetRoleRequest) error {
+	// Make sure the role exists within the account before deleting its assignments and statements.
+	if _, err := s.repo.Get(ctx, request); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, request)
+}
+
+func (s *service) GetRole(ctx context.Context, request *GetRoleRequest) (*Role, error) {
+	return s.repo.Get(ctx, request)
+}
+
+func (s *service) ListRoles(ctx context.Context, accountID int64) ([]Role, error) {
+	return s.repo.List(ctx, accountID)
+}
+
+func (s *service) AssignRole(ctx context.Context, assignment *Assignment) error {
+	// Make sure the role exists within the account before assigning it.
+	if _, err := s.repo.Get(ctx, &GetRoleRequest{
+		AccountID: assignment.AccountID,
+		RoleID:    assignment.RoleID,
+	}); err != nil {
+		return err
+	}
+
+	// Only members of the account can hold its roles, like groups.AddMember.
+	isMember, err := s.repo.IsAccountMember(ctx, &GetByTeamMemberRequest{
+		AccountID:    assignment.AccountID,
+		TeamMemberID: assignment.TeamMemberID,
+	})
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrNotAccountMember
+	}
+
+	return s.repo.Assign(ctx, assignment)
+}
+
+func (s *service) UnassignRole(ctx context.Context, assignment *Assignment) error {
+	return s.repo.Unassign(ctx, assignment)
+}
+
+func (s *service) GetGrants(ctx context.Context, request *policies.GetPolicyRequest) ([]policies.Policy, error) {
+	assignedRoles, err := s.repo.GetByTeamMember(ctx, &GetByTeamMemberRequest{
+		AccountID:    request.AccountID,
+		TeamMemberID: request.TeamMemberID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.resolveInheritance(ctx, request.AccountID, assignedRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []policies.Policy
+	for _, role := range roles {
+		for _, statement := range role.Statements {
+			if !slices.Contains(request.Actions, statement.Action) {
+				continue
+			}
+
+			grants = append(grants, policies.Policy{
+				AccountID:    request.AccountID,
+				TeamMemberID: request.TeamMemberID,
+				RoleID:       role.ID,
+				Resource:     statement.Resource,
+				Action:       statement.Action,
+				Effect:       statement.Effect,
+			})
+		}
+	}
+
+	return grants, nil
+}
+
+func (s *service) GetGrantees(ctx context.Context, accountID int64) ([]int64, error) {
+	return s.repo.GetAssignedTeamMemberIDs(ctx, accountID)
+}
@@ -0,0 +1,392 @@
+package roles_test
+
+import (
+	"testing"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/adhikag24/policy-based-permission-model/domain/roles"
+	mockRepository "github.com/adhikag24/policy-based-permission-model/domain/roles/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type test struct {
+	mockRepository *mockRepository.MockRepository
+}
+
+func setup(ctrl *gomock.Controller) *test {
+	return &test{
+		mockRepository: mockRepository.NewMockRepository(ctrl),
+	}
+}
+
+func TestCreateRole(t *testing.T) {
+	t.Run("Successfully creates role and defaults statement effect to allow", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Create(gomock.Any(), &roles.Role{
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "blogs/*", Action: policies.ActionWrite, Effect: policies.EffectAllow},
+			},
+		}).Return(&roles.Role{ID: 1, AccountID: 100, Name: "Editor"}, nil)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "blogs/*", Action: policies.ActionWrite},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), role.ID)
+	})
+
+	t.Run("Rejects role without name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{AccountID: 100})
+
+		assert.ErrorIs(t, err, roles.ErrRoleNameRequired)
+		assert.Nil(t, role)
+	})
+
+	t.Run("Rejects role with invalid statement", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "blogs/*"},
+			},
+		})
+
+		assert.ErrorIs(t, err, roles.ErrInvalidStatement)
+		assert.Nil(t, role)
+	})
+
+	t.Run("Successfully creates role with normalized statement resource", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Create(gomock.Any(), &roles.Role{
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "blogs/*", Action: policies.ActionWrite, Effect: policies.EffectAllow},
+			},
+		}).Return(&roles.Role{ID: 1}, nil)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "/blogs//*/", Action: policies.ActionWrite},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), role.ID)
+	})
+
+	t.Run("Rejects statement with malformed resource pattern", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "blog*", Action: policies.ActionWrite},
+			},
+		})
+
+		assert.ErrorIs(t, err, roles.ErrInvalidStatement)
+		assert.ErrorIs(t, err, policies.ErrInvalidResource)
+		assert.Nil(t, role)
+	})
+
+	t.Run("Rejects statement with unregistered action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "blogs/*", Action: policies.ActionPublish},
+			},
+		})
+
+		assert.ErrorIs(t, err, roles.ErrInvalidStatement)
+		assert.ErrorIs(t, err, policies.ErrUnknownAction)
+		assert.Nil(t, role)
+	})
+
+	t.Run("Rejects statement on resource matching no registered template", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		registry := policies.NewResourceRegistry()
+		registry.Register(policies.ResourceTemplate{Template: "blogs/{blogID}", Actions: []policies.Action{policies.ActionRead}})
+		service := roles.NewService(test.mockRepository, roles.WithResourceRegistry(registry))
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{
+			AccountID: 100,
+			Name:      "Viewer",
+			Statements: []roles.Statement{
+				{Resource: "blogs/1/comments/2", Action: policies.ActionRead},
+			},
+		})
+
+		assert.ErrorIs(t, err, roles.ErrInvalidStatement)
+		assert.ErrorIs(t, err, policies.ErrUnknownResource)
+		assert.Nil(t, role)
+	})
+}
+
+func TestAssignRole(t *testing.T) {
+	t.Run("Successfully assigns role to account member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		assignment := &roles.Assignment{AccountID: 100, RoleID: 1, TeamMemberID: 200}
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&roles.Role{ID: 1, AccountID: 100}, nil)
+		test.mockRepository.EXPECT().IsAccountMember(gomock.Any(), &roles.GetByTeamMemberRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+		}).Return(true, nil)
+		test.mockRepository.EXPECT().Assign(gomock.Any(), assignment).Return(nil)
+		service := roles.NewService(test.mockRepository)
+
+		err := service.AssignRole(t.Context(), assignment)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Rejects team member outside the account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&roles.Role{ID: 1, AccountID: 100}, nil)
+		test.mockRepository.EXPECT().IsAccountMember(gomock.Any(), gomock.Any()).Return(false, nil)
+		service := roles.NewService(test.mockRepository)
+
+		err := service.AssignRole(t.Context(), &roles.Assignment{AccountID: 100, RoleID: 1, TeamMemberID: 300})
+
+		assert.ErrorIs(t, err, roles.ErrNotAccountMember)
+	})
+
+	t.Run("Rejects assignment of role outside the account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &roles.GetRoleRequest{
+			AccountID: 100,
+			RoleID:    1,
+		}).Return(nil, roles.ErrRoleNotFound)
+		service := roles.NewService(test.mockRepository)
+
+		err := service.AssignRole(t.Context(), &roles.Assignment{
+			AccountID:    100,
+			RoleID:       1,
+			TeamMemberID: 200,
+		})
+
+		assert.ErrorIs(t, err, roles.ErrRoleNotFound)
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	t.Run("Successfully deletes role of the account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		request := &roles.GetRoleRequest{AccountID: 100, RoleID: 1}
+		test.mockRepository.EXPECT().Get(gomock.Any(), request).Return(&roles.Role{ID: 1, AccountID: 100, Name: "Editor"}, nil)
+		test.mockRepository.EXPECT().Delete(gomock.Any(), request).Return(nil)
+		service := roles.NewService(test.mockRepository)
+
+		err := service.DeleteRole(t.Context(), request)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Leaves role of another account untouched", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &roles.GetRoleRequest{
+			AccountID: 100,
+			RoleID:    2,
+		}).Return(nil, roles.ErrRoleNotFound)
+		service := roles.NewService(test.mockRepository)
+
+		err := service.DeleteRole(t.Context(), &roles.GetRoleRequest{AccountID: 100, RoleID: 2})
+
+		assert.ErrorIs(t, err, roles.ErrRoleNotFound)
+	})
+}
+
+func TestGetGrants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	test := setup(ctrl)
+	test.mockRepository.EXPECT().GetByTeamMember(gomock.Any(), &roles.GetByTeamMemberRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+	}).Return([]roles.Role{
+		{
+			ID:        1,
+			AccountID: 100,
+			Name:      "Editor",
+			Statements: []roles.Statement{
+				{Resource: "blogs/*", Action: policies.ActionWrite, Effect: policies.EffectAllow},
+				{Resource: "funnels/*", Action: policies.ActionRead, Effect: policies.EffectAllow},
+			},
+		},
+	}, nil)
+	service := roles.NewService(test.mockRepository)
+
+	grants, err := service.GetGrants(t.Context(), &policies.GetPolicyRequest{
+		AccountID:    100,
+		TeamMemberID: 200,
+		Actions:      []policies.Action{policies.ActionWrite},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []policies.Policy{
+		{
+			AccountID:    100,
+			TeamMemberID: 200,
+			RoleID:       1,
+			Resource:     "blogs/*",
+			Action:       policies.ActionWrite,
+			Effect:       policies.EffectAllow,
+		},
+	}, grants)
+}
+
+func TestRoleInheritance(t *testing.T) {
+	accountRoles := []roles.Role{
+		{
+			ID: 1, AccountID: 100, Name: "Viewer",
+			Statements: []roles.Statement{{Resource: "blogs/*", Action: policies.ActionRead, Effect: policies.EffectAllow}},
+		},
+		{
+			ID: 2, AccountID: 100, Name: "Editor", ParentRoleIDs: []int64{1},
+			Statements: []roles.Statement{{Resource: "blogs/*", Action: policies.ActionWrite, Effect: policies.EffectAllow}},
+		},
+		{
+			ID: 3, AccountID: 100, Name: "Admin", ParentRoleIDs: []int64{2},
+			Statements: []roles.Statement{{Resource: "funnels/*", Action: policies.ActionWrite, Effect: policies.EffectAllow}},
+		},
+	}
+
+	t.Run("Rejects update that introduces an inheritance cycle", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().List(gomock.Any(), int64(100)).Return(accountRoles, nil)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.UpdateRole(t.Context(), &roles.Role{
+			ID: 1, AccountID: 100, Name: "Viewer", ParentRoleIDs: []int64{3},
+		})
+
+		assert.ErrorIs(t, err, roles.ErrInheritanceCycle)
+		assert.Nil(t, role)
+	})
+
+	t.Run("Rejects role inheriting from itself", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().List(gomock.Any(), int64(100)).Return(accountRoles, nil)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.UpdateRole(t.Context(), &roles.Role{
+			ID: 2, AccountID: 100, Name: "Editor", ParentRoleIDs: []int64{2},
+		})
+
+		assert.ErrorIs(t, err, roles.ErrInheritanceCycle)
+		assert.Nil(t, role)
+	})
+
+	t.Run("Rejects unknown parent role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().List(gomock.Any(), int64(100)).Return(accountRoles, nil)
+		service := roles.NewService(test.mockRepository)
+
+		role, err := service.CreateRole(t.Context(), &roles.Role{
+			AccountID: 100, Name: "Owner", ParentRoleIDs: []int64{99},
+		})
+
+		assert.ErrorIs(t, err, roles.ErrRoleNotFound)
+		assert.Nil(t, role)
+	})
+
+	t.Run("Grants include transitively inherited statements", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().GetByTeamMember(gomock.Any(), gomock.Any()).Return([]roles.Role{accountRoles[2]}, nil)
+		test.mockRepository.EXPECT().List(gomock.Any(), int64(100)).Return(accountRoles, nil)
+		service := roles.NewService(test.mockRepository)
+
+		grants, err := service.GetGrants(t.Context(), &policies.GetPolicyRequest{
+			AccountID:    100,
+			TeamMemberID: 200,
+			Actions:      []policies.Action{policies.ActionRead},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []policies.Policy{
+			{
+				AccountID:    100,
+				TeamMemberID: 200,
+				RoleID:       1,
+				Resource:     "blogs/*",
+				Action:       policies.ActionRead,
+				Effect:       policies.EffectAllow,
+			},
+		}, grants)
+	})
+
+	t.Run("Flatten returns effective statements of the role hierarchy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		test := setup(ctrl)
+		test.mockRepository.EXPECT().Get(gomock.Any(), &roles.GetRoleRequest{AccountID: 100, RoleID: 3}).
+			Return(&accountRoles[2], nil)
+		test.mockRepository.EXPECT().List(gomock.Any(), int64(100)).Return(accountRoles, nil)
+		service := roles.NewService(test.mockRepository)
+
+		statements, err := service.FlattenRole(t.Context(), &roles.GetRoleRequest{AccountID: 100, RoleID: 3})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []roles.EffectiveStatement{
+			{Statement: accountRoles[2].Statements[0], RoleID: 3},
+			{Statement: accountRoles[1].Statements[0], RoleID: 2},
+			{Statement: accountRoles[0].Statements[0], RoleID: 1},
+		}, statements)
+	})
+}
@@ -3,11 +3,15 @@ package http
 import (
 	handlersblogs "github.com/adhikag24/policy-based-permission-model/http/handlers/blogs"
 	handlersfunnels "github.com/adhikag24/policy-based-permission-model/http/handlers/funnels"
+	handlersgroups "github.com/adhikag24/policy-based-permission-model/http/handlers/groups"
 	handlerspolicies "github.com/adhikag24/policy-based-permission-model/http/handlers/policies"
+	handlersroles "github.com/adhikag24/policy-based-permission-model/http/handlers/roles"
 )
 
 type Handlers struct {
 	Policies *handlerspolicies.Handler
 	Funnels  *handlersfunnels.Handler
 	Blogs    *handlersblogs.Handler
+	Roles    *handlersroles.Handler
+	Groups   *handlersgroups.Handler
 }
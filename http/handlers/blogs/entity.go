@@ -18,3 +18,8 @@ type WriteBlogSettingsRequest struct {
 	Title   string `json:"title"`
 	Content string `json:"content"`
 }
+
+type PublishBlogPageRequest struct {
+	BlogID string `json:"blog_id"`
+	PageID string `json:"page_id"`
+}
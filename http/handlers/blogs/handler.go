@@ -41,6 +41,7 @@ func (h *Handler) WriteBlogPage(c *echo.Context) error {
 		TeamMemberID: teamMemberID,
 		PageID:       request.Data.PageID,
 		Content:      request.Data.Content,
+		Attributes:   shared.GetConditionAttributes(c, nil),
 	})
 	if err != nil {
 		return h.handleErrorResponse(c, handleErrorResponseSpec{
@@ -80,6 +81,7 @@ func (h *Handler) ReadBlogPage(c *echo.Context) error {
 		TeamMemberID: teamMemberID,
 		PageID:       pageID,
 		BlogID:       blogID,
+		Attributes:   shared.GetConditionAttributes(c, nil),
 	})
 	if err != nil {
 		return h.handleErrorResponse(c, handleErrorResponseSpec{
@@ -117,6 +119,7 @@ func (h *Handler) ReadBlogSettings(c *echo.Context) error {
 		AccountID:    accountID,
 		TeamMemberID: teamMemberID,
 		BlogID:       blogID,
+		Attributes:   shared.GetConditionAttributes(c, nil),
 	})
 	if err != nil {
 		return h.handleErrorResponse(c, handleErrorResponseSpec{
@@ -157,6 +160,7 @@ func (h *Handler) WriteBlogSettings(c *echo.Context) error {
 		BlogID:       request.Data.BlogID,
 		Title:        request.Data.Title,
 		Content:      request.Data.Content,
+		Attributes:   shared.GetConditionAttributes(c, nil),
 	})
 	if err != nil {
 		return h.handleErrorResponse(c, handleErrorResponseSpec{
@@ -174,6 +178,48 @@ func (h *Handler) WriteBlogSettings(c *echo.Context) error {
 	})
 }
 
+func (h *Handler) PublishBlogPage(c *echo.Context) error {
+	var request CommonRequest[PublishBlogPageRequest]
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	accountID, teamMemberID, err := h.getMandatoryHeaders(c)
+	if err != nil {
+		return c.JSON(400, shared.Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrMissingMandatoryHeaders",
+					Message: "Missing mandatory headers",
+				},
+			},
+		})
+	}
+
+	err = h.blogsService.PublishBlogPage(c.Request().Context(), &blogs.PublishBlogPageRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+		BlogID:       request.Data.BlogID,
+		PageID:       request.Data.PageID,
+		Attributes:   shared.GetConditionAttributes(c, nil),
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, handleErrorResponseSpec{
+			err:                     err,
+			permissionDeniedCode:    "ErrPermissionDenied",
+			permissionDeniedMessage: "Permission denied to publish blog page",
+			genericErrorCode:        "ErrFailedToPublishBlogPage",
+			genericErrorMessage:     "Failed to publish blog page",
+		})
+	}
+
+	return c.JSON(200, Response[any]{
+		Code:    200,
+		Message: "Successfully published blog page",
+	})
+}
+
 type handleErrorResponseSpec struct {
 	err                     error
 	permissionDeniedCode    string
@@ -202,6 +248,9 @@ func (h *Handler) handleErrorResponse(c *echo.Context, spec handleErrorResponseS
 			},
 		})
 	}
+	if response, ok := shared.GetPermissionCheckErrorResponse(err); ok {
+		return c.JSON(response.Code, response)
+	}
 	return c.JSON(500, Response[any]{
 		Code: 500,
 		Errors: []shared.Errors{
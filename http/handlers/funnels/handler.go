@@ -39,6 +39,7 @@ func (h *Handler) CreateFunnel(c *echo.Context) error {
 		AccountID:    accountID,
 		TeamMemberID: teamMemberID,
 		Name:         request.Data.Name,
+		Attributes:   shared.GetConditionAttributes(c, nil),
 	})
 	if err != nil {
 		return h.handleErrorResponse(c, handleErrorResponseSpec{
@@ -72,6 +73,7 @@ func (h *Handler) GetFunnel(c *echo.Context) error {
 		AccountID:    accountID,
 		TeamMemberID: teamMemberID,
 		FunnelID:     funnelID,
+		Attributes:   shared.GetConditionAttributes(c, nil),
 	})
 	if err != nil {
 		return h.handleErrorResponse(c, handleErrorResponseSpec{
@@ -90,6 +92,40 @@ func (h *Handler) GetFunnel(c *echo.Context) error {
 	})
 }
 
+func (h *Handler) DeleteFunnel(c *echo.Context) error {
+	funnelID := c.Param("id")
+
+	// Mandatory headers.
+	accountID, teamMemberID, err := h.getMandatoryHeaders(c)
+	if err != nil {
+		return c.JSON(400, shared.Response[any]{
+			Code:    400,
+			Message: "Missing mandatory headers",
+		})
+	}
+
+	err = h.service.DeleteFunnel(c.Request().Context(), &funnels.DeleteFunnelRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+		FunnelID:     funnelID,
+		Attributes:   shared.GetConditionAttributes(c, nil),
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, handleErrorResponseSpec{
+			err:                     err,
+			permissionDeniedCode:    "ErrPermissionDenied",
+			permissionDeniedMessage: "Permission denied to delete funnel",
+			genericErrorCode:        "ErrFailedToDeleteFunnel",
+			genericErrorMessage:     "Failed to delete funnel",
+		})
+	}
+
+	return c.JSON(200, shared.Response[any]{
+		Code:    200,
+		Message: "Successfully deleted funnel",
+	})
+}
+
 type handleErrorResponseSpec struct {
 	err                     error
 	permissionDeniedCode    string
@@ -118,6 +154,9 @@ func (h *Handler) handleErrorResponse(c *echo.Context, spec handleErrorResponseS
 			},
 		})
 	}
+	if response, ok := shared.GetPermissionCheckErrorResponse(err); ok {
+		return c.JSON(response.Code, response)
+	}
 	return c.JSON(500, Response[any]{
 		Code: 500,
 		Errors: []shared.Errors{
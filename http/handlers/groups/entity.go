@@ -0,0 +1,18 @@
+package handlersgroups
+
+import "github.com/adhikag24/policy-based-permission-model/http/handlers/shared"
+
+type Group struct {
+	ID        int64  `json:"id"`
+	AccountID int64  `json:"account_id"`
+	Name      string `json:"name"`
+}
+
+type AddMemberRequest struct {
+	TeamMemberID int64 `json:"team_member_id"`
+}
+
+type (
+	CommonRequest[T any] shared.CommonRequest[T]
+	Response[T any]      shared.Response[T]
+)
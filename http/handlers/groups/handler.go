@@ -0,0 +1,214 @@
+package handlersgroups
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/groups"
+	"github.com/adhikag24/policy-based-permission-model/http/handlers/shared"
+	"github.com/labstack/echo/v5"
+)
+
+type Handler struct {
+	service groups.Service
+}
+
+func NewHandler(service groups.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) CreateGroup(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	var request CommonRequest[Group]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	group, err := h.service.CreateGroup(c.Request().Context(), &groups.Group{
+		AccountID: accountID,
+		Name:      request.Data.Name,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToCreateGroup", "Failed to create group")
+	}
+
+	return c.JSON(201, Response[*Group]{
+		Code:    201,
+		Message: "Successfully created group",
+		Data:    fromDomainGroup(group),
+	})
+}
+
+func (h *Handler) ListGroups(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	domainGroups, err := h.service.ListGroups(c.Request().Context(), accountID)
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToListGroups", "Failed to list groups")
+	}
+
+	responseGroups := make([]*Group, 0, len(domainGroups))
+	for i := range domainGroups {
+		responseGroups = append(responseGroups, fromDomainGroup(&domainGroups[i]))
+	}
+
+	return c.JSON(200, Response[[]*Group]{
+		Code:    200,
+		Message: "Successfully listed groups",
+		Data:    responseGroups,
+	})
+}
+
+func (h *Handler) DeleteGroup(c *echo.Context) error {
+	accountID, groupID, err := h.getGroupPathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	err = h.service.DeleteGroup(c.Request().Context(), &groups.GetGroupRequest{
+		AccountID: accountID,
+		GroupID:   groupID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToDeleteGroup", "Failed to delete group")
+	}
+
+	return c.JSON(200, Response[any]{
+		Code:    200,
+		Message: "Successfully deleted group",
+	})
+}
+
+func (h *Handler) AddMember(c *echo.Context) error {
+	accountID, groupID, err := h.getGroupPathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	var request CommonRequest[AddMemberRequest]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	err = h.service.AddMember(c.Request().Context(), &groups.Membership{
+		AccountID:    accountID,
+		GroupID:      groupID,
+		TeamMemberID: request.Data.TeamMemberID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToAddGroupMember", "Failed to add group member")
+	}
+
+	return c.JSON(201, Response[any]{
+		Code:    201,
+		Message: "Successfully added group member",
+	})
+}
+
+func (h *Handler) RemoveMember(c *echo.Context) error {
+	accountID, groupID, err := h.getGroupPathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	teamMemberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	err = h.service.RemoveMember(c.Request().Context(), &groups.Membership{
+		AccountID:    accountID,
+		GroupID:      groupID,
+		TeamMemberID: teamMemberID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToRemoveGroupMember", "Failed to remove group member")
+	}
+
+	return c.JSON(200, Response[any]{
+		Code:    200,
+		Message: "Successfully removed group member",
+	})
+}
+
+func (h *Handler) handleErrorResponse(c *echo.Context, err error, genericErrorCode, genericErrorMessage string) error {
+	switch {
+	case errors.Is(err, groups.ErrGroupNotFound):
+		return c.JSON(404, Response[any]{
+			Code: 404,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrGroupNotFound",
+					Message: "Group not found",
+				},
+			},
+		})
+	case errors.Is(err, groups.ErrGroupNameRequired), errors.Is(err, groups.ErrNotAccountMember):
+		return c.JSON(400, Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidGroupRequest",
+					Message: err.Error(),
+				},
+			},
+		})
+	}
+
+	return c.JSON(500, Response[any]{
+		Code: 500,
+		Errors: []shared.Errors{
+			{
+				Code:    genericErrorCode,
+				Message: genericErrorMessage,
+			},
+		},
+	})
+}
+
+func (h *Handler) invalidPathParamResponse(c *echo.Context) error {
+	return c.JSON(400, Response[any]{
+		Code: 400,
+		Errors: []shared.Errors{
+			{
+				Code:    "ErrInvalidPathParam",
+				Message: "Invalid path parameter",
+			},
+		},
+	})
+}
+
+func (h *Handler) getGroupPathParams(c *echo.Context) (accountID int64, groupID int64, err error) {
+	accountID, err = strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	groupID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return accountID, groupID, nil
+}
+
+func fromDomainGroup(group *groups.Group) *Group {
+	return &Group{
+		ID:        group.ID,
+		AccountID: group.AccountID,
+		Name:      group.Name,
+	}
+}
@@ -1,6 +1,10 @@
 package handlerspolicies
 
-import "github.com/adhikag24/policy-based-permission-model/http/handlers/shared"
+import (
+	"time"
+
+	"github.com/adhikag24/policy-based-permission-model/http/handlers/shared"
+)
 
 type CheckPermissionRequest struct {
 	AccountID    int64 `json:"account_id"`
@@ -9,14 +13,192 @@ type CheckPermissionRequest struct {
 	Resource string `json:"resource"`
 	// Action to be performed.
 	Action string `json:"action"`
+	// Request attributes evaluated by policy conditions. E.g., {"member.department": "marketing"}
+	// Built-in ip and time attributes are always set by the server.
+	Context map[string]string `json:"context,omitempty"`
+	// Checks against the policies as they were at the time, in RFC 3339. E.g., 2026-10-13T14:00:00+07:00
+	// Decisions a role or group grant contributed to are refused, as roles and group memberships have no history.
+	AsOf *time.Time `json:"as_of,omitempty"`
+}
+
+type CheckPermissionsRequest struct {
+	AccountID    int64                 `json:"account_id"`
+	TeamMemberID int64                 `json:"team_member_id"`
+	Items        []PermissionCheckItem `json:"items"`
+	// Request attributes shared by every item, see CheckPermissionRequest.
+	Context map[string]string `json:"context,omitempty"`
+	AsOf    *time.Time        `json:"as_of,omitempty"` // See CheckPermissionRequest.
+}
+
+type PermissionCheckItem struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// PermissionCheckResult is returned in the same order as the requested items.
+type PermissionCheckResult struct {
+	Resource string    `json:"resource"`
+	Action   string    `json:"action"`
+	Allowed  bool      `json:"allowed"`
+	Decision *Decision `json:"decision,omitempty"` // Only set with explain=true.
+}
+
+type AccessibleResources struct {
+	Allowed []AccessibleResource `json:"allowed"`
+	Denied  []AccessibleResource `json:"denied"` // Carve-outs from the allowed patterns.
+}
+
+// AccessibleResource is an exact resource or a wildcard pattern. E.g., funnels/12, funnels/*
+type AccessibleResource struct {
+	Resource  string  `json:"resource"`
+	Rule      string  `json:"rule"` // exact, wildcard, root, or parent-read.
+	Condition string  `json:"condition,omitempty"`
+	PolicyIDs []int64 `json:"policy_ids,omitempty"`
+	RoleIDs   []int64 `json:"role_ids,omitempty"`
+}
+
+type AuthorizedMember struct {
+	TeamMemberID int64     `json:"team_member_id"`
+	Decision     *Decision `json:"decision"`
+}
+
+type ActionPermissions struct {
+	Action  string           `json:"action"`
+	Allowed []EffectiveGrant `json:"allowed"`
+	Denied  []EffectiveGrant `json:"denied"`
+}
+
+type EffectiveGrant struct {
+	Resource  string        `json:"resource"`
+	Condition string        `json:"condition,omitempty"`
+	NotBefore *time.Time    `json:"not_before,omitempty"`
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+	Sources   []GrantSource `json:"sources"`
+}
+
+// GrantSource sets policy_id for direct and group policies, and role_id for roles.
+type GrantSource struct {
+	PolicyID int64  `json:"policy_id,omitempty"`
+	GroupID  int64  `json:"group_id,omitempty"`
+	RoleID   int64  `json:"role_id,omitempty"`
+	Action   string `json:"action"` // Differs from the granted action when implied. E.g., write grants read.
 }
 
+// SimulationRequest applies Add and RemovePolicyIDs in memory, then runs Checks.
+// Added policies are referenced by negative IDs in decisions, in order: -1, -2, ...
+type SimulationRequest struct {
+	Add             []Policy                 `json:"add"`
+	RemovePolicyIDs []int64                  `json:"remove_policy_ids"`
+	Checks          []CheckPermissionRequest `json:"checks"`
+}
+
+type SimulationResult struct {
+	TeamMemberID int64     `json:"team_member_id"`
+	Resource     string    `json:"resource"`
+	Action       string    `json:"action"`
+	Decision     *Decision `json:"decision"`
+}
+
+// CreatePolicyPlan is returned by POST /v1/policies?dry_run=true.
+type CreatePolicyPlan struct {
+	Policy *Policy `json:"policy"`
+	// IsRedundant means the policy would be rejected since broader_policy_id already covers it.
+	IsRedundant       bool      `json:"is_redundant"`
+	BroaderPolicyID   int64     `json:"broader_policy_id,omitempty"`
+	DeletedPolicyIDs  []int64   `json:"deleted_policy_ids,omitempty"`
+	ResultingPolicies []*Policy `json:"resulting_policies"`
+}
+
+// Decision is only returned when the check is requested with explain=true.
+type Decision struct {
+	Allowed   bool    `json:"allowed"`
+	PolicyIDs []int64 `json:"policy_ids,omitempty"`
+	RoleIDs   []int64 `json:"role_ids,omitempty"`
+	Rule      string  `json:"rule,omitempty"`   // exact, wildcard, root, or parent-read.
+	Reason    string  `json:"reason,omitempty"` // E.g., no_matching_policy, explicit_deny
+}
+
+// Policy targets either a team member or a group, not both.
 type Policy struct {
 	ID           int64  `json:"id"`
 	AccountID    int64  `json:"account_id"`
-	TeamMemberID int64  `json:"team_member_id"`
+	TeamMemberID int64  `json:"team_member_id,omitempty"`
+	GroupID      int64  `json:"group_id,omitempty"`
 	Resource     string `json:"resource"`
 	Action       string `json:"action"`
+	Effect       string `json:"effect,omitempty"` // allow (default) or deny.
+	Condition    string `json:"condition,omitempty"`
+	// Optional validity window in RFC 3339. E.g., 2026-01-31T18:00:00Z
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// StatementOutcome reports every policy a document statement expanded into.
+type StatementOutcome struct {
+	Statement int              `json:"statement"`
+	ID        string           `json:"sid,omitempty"`
+	Policies  []ImportedPolicy `json:"policies"`
+}
+
+type ImportedPolicy struct {
+	Resource string  `json:"resource"`
+	Action   string  `json:"action"`
+	Status   string  `json:"status"` // created, redundant, or rejected.
+	PolicyID int64   `json:"policy_id,omitempty"`
+	Error    *Errors `json:"error,omitempty"` // Only set when rejected.
+}
+
+// ParseRulesRequest holds rule text, one rule per line. E.g.,
+// allow write on blogs/12/* to member 2 in account 1 when time < 18:00
+type ParseRulesRequest struct {
+	Text string `json:"text"`
+}
+
+type ParseRulesResult struct {
+	Formatted string    `json:"formatted"` // Canonical form of the text, without comments and blank lines.
+	Policies  []*Policy `json:"policies"`
+}
+
+// RulePosition is where rule text fails to parse. Lines and columns start at 1.
+type RulePosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// LintFinding is a problem found on policy_id. E.g., redundant_policy, with the broader policy as related_policy_id.
+type LintFinding struct {
+	Kind            string `json:"kind"`
+	PolicyID        int64  `json:"policy_id"`
+	RelatedPolicyID int64  `json:"related_policy_id,omitempty"`
+	Message         string `json:"message"`
+	IsFixable       bool   `json:"is_fixable"`
+	IsFixed         bool   `json:"is_fixed"`
+}
+
+// Revision records one policy mutation. Before is omitted when created, After when deleted.
+type Revision struct {
+	ID           int64     `json:"id"`
+	AccountID    int64     `json:"account_id"`
+	TeamMemberID int64     `json:"team_member_id,omitempty"`
+	GroupID      int64     `json:"group_id,omitempty"`
+	PolicyID     int64     `json:"policy_id"`
+	Operation    string    `json:"operation"` // created, deleted, or updated.
+	Actor        string    `json:"actor,omitempty"`
+	Before       *Policy   `json:"before,omitempty"`
+	After        *Policy   `json:"after,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RollbackResult lists the deleted policies and the outcome of every recreated one, which gets a new ID.
+type RollbackResult struct {
+	DeletedPolicyIDs []int64          `json:"deleted_policy_ids"`
+	Created          []ImportedPolicy `json:"created"`
+}
+
+// ResourceTemplate is a registered resource shape. E.g., blogs/{blogID}/pages/{pageID}
+type ResourceTemplate struct {
+	Template string   `json:"template"`
+	Actions  []string `json:"actions"`
 }
 
 type (
@@ -1,7 +1,11 @@
 package handlerspolicies
 
 import (
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
 
 	"github.com/adhikag24/policy-based-permission-model/domain/policies"
 	"github.com/adhikag24/policy-based-permission-model/http/handlers/shared"
@@ -26,12 +30,11 @@ func (h *Handler) CreatePolicy(c *echo.Context) error {
 	}
 
 	requestContext := c.Request().Context()
-	policyDomainRequest := policies.Policy{
-		AccountID:    request.Data.AccountID,
-		TeamMemberID: request.Data.TeamMemberID,
-		Resource:     request.Data.Resource,
-		Action:       policies.Action(request.Data.Action),
+	policyDomainRequest := toDomainPolicy(request.Data)
+	if c.QueryParam("dry_run") == "true" {
+		return h.dryRunCreatePolicy(c, &policyDomainRequest)
 	}
+
 	policy, err := h.service.CreatePolicy(requestContext, &policyDomainRequest)
 	if err != nil {
 		if errors.Is(err, policies.ErrUserAlreadyHasBroaderPolicy) {
@@ -41,6 +44,9 @@ func (h *Handler) CreatePolicy(c *echo.Context) error {
 				Message: "Successfully created policy",
 			})
 		}
+		if response, ok := getPolicyErrorResponse(err); ok {
+			return c.JSON(response.Code, response)
+		}
 		// Generic error response.
 		return c.JSON(500, Response[any]{
 			Code: 500,
@@ -53,19 +59,163 @@ func (h *Handler) CreatePolicy(c *echo.Context) error {
 		})
 	}
 
-	responsePolicy := &Policy{
+	return c.JSON(201, Response[*Policy]{
+		Code:    201,
+		Message: "Successfully created policy",
+		Data:    fromDomainPolicy(policy),
+	})
+}
+
+func (h *Handler) dryRunCreatePolicy(c *echo.Context, policy *policies.Policy) error {
+	requestContext := c.Request().Context()
+	plan, err := h.service.DryRunCreatePolicy(requestContext, policy)
+	if err != nil {
+		if response, ok := getPolicyErrorResponse(err); ok {
+			return c.JSON(response.Code, response)
+		}
+		slog.ErrorContext(requestContext, "failed to dry run policy creation", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToCreatePolicy",
+					Message: "Failed to create policy",
+				},
+			},
+		})
+	}
+
+	resultingPolicies := make([]*Policy, 0, len(plan.ResultingPolicies))
+	for i := range plan.ResultingPolicies {
+		resultingPolicies = append(resultingPolicies, fromDomainPolicy(&plan.ResultingPolicies[i]))
+	}
+
+	return c.JSON(200, Response[*CreatePolicyPlan]{
+		Code:    200,
+		Message: "Dry run, nothing was changed",
+		Data: &CreatePolicyPlan{
+			Policy:            fromDomainPolicy(plan.Policy),
+			IsRedundant:       plan.IsRedundant,
+			BroaderPolicyID:   plan.BroaderPolicyID,
+			DeletedPolicyIDs:  plan.DeletedPolicyIDs,
+			ResultingPolicies: resultingPolicies,
+		},
+	})
+}
+
+func toDomainPolicy(policy Policy) policies.Policy {
+	return policies.Policy{
+		AccountID:    policy.AccountID,
+		TeamMemberID: policy.TeamMemberID,
+		GroupID:      policy.GroupID,
+		Resource:     policy.Resource,
+		Action:       policies.Action(policy.Action),
+		Effect:       policies.Effect(policy.Effect),
+		Condition:    policy.Condition,
+		NotBefore:    policy.NotBefore,
+		ExpiresAt:    policy.ExpiresAt,
+	}
+}
+
+func fromDomainPolicy(policy *policies.Policy) *Policy {
+	if policy == nil {
+		return nil
+	}
+
+	return &Policy{
 		ID:           policy.ID,
 		AccountID:    policy.AccountID,
 		TeamMemberID: policy.TeamMemberID,
+		GroupID:      policy.GroupID,
 		Resource:     policy.Resource,
 		Action:       string(policy.Action),
+		Effect:       string(policy.Effect),
+		Condition:    policy.Condition,
+		NotBefore:    policy.NotBefore,
+		ExpiresAt:    policy.ExpiresAt,
 	}
+}
 
-	return c.JSON(201, Response[*Policy]{
-		Code:    201,
-		Message: "Successfully created policy",
-		Data:    responsePolicy,
-	})
+// getPolicyErrorResponse maps policy validation errors to client error responses.
+func getPolicyErrorResponse(err error) (Response[any], bool) {
+	if errors.Is(err, policies.ErrInvalidEffect) {
+		return Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidEffect",
+					Message: "Effect must be either allow or deny",
+				},
+			},
+		}, true
+	}
+	if errors.Is(err, policies.ErrInvalidValidity) {
+		return Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidValidity",
+					Message: "expires_at must be after not_before",
+				},
+			},
+		}, true
+	}
+	if errors.Is(err, policies.ErrInvalidPrincipal) {
+		return Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidPrincipal",
+					Message: "Policy must target exactly one of team_member_id or group_id",
+				},
+			},
+		}, true
+	}
+	if errors.Is(err, policies.ErrInvalidResource) {
+		return Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidResource",
+					Message: "Resource must be a non-empty path where * and ** only appear as whole segments",
+				},
+			},
+		}, true
+	}
+	if errors.Is(err, policies.ErrUnknownResource) {
+		return Response[any]{
+			Code: 422,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrUnknownResource",
+					Message: "Resource matches no registered resource template",
+				},
+			},
+		}, true
+	}
+	if errors.Is(err, policies.ErrUnknownAction) {
+		return Response[any]{
+			Code: 422,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrUnknownAction",
+					Message: "Action is not registered for the resource type",
+				},
+			},
+		}, true
+	}
+	if errors.Is(err, policies.ErrInvalidCondition) {
+		return Response[any]{
+			Code: 422,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidCondition",
+					Message: err.Error(),
+				},
+			},
+		}, true
+	}
+	return Response[any]{}, false
 }
 
 func (h *Handler) DeletePolicy(c *echo.Context) error {
@@ -112,14 +262,37 @@ func (h *Handler) CheckPermission(c *echo.Context) error {
 	}
 
 	requestContext := c.Request().Context()
-	isPermitted := h.service.CheckPermission(requestContext, &policies.CheckPermissionRequest{
+	decision, err := h.service.CheckPermission(requestContext, &policies.CheckPermissionRequest{
 		AccountID:    request.Data.AccountID,
 		TeamMemberID: request.Data.TeamMemberID,
 		Resource:     request.Data.Resource,
 		Action:       policies.Action(request.Data.Action),
+		Attributes:   shared.GetConditionAttributes(c, request.Data.Context),
+		AsOf:         request.Data.AsOf,
 	})
-	if !isPermitted {
-		return c.JSON(403, Response[any]{
+	if err != nil {
+		if response, ok := getHistoryErrorResponse(err); ok {
+			return c.JSON(response.Code, response)
+		}
+		slog.ErrorContext(requestContext, "failed to check permission", "error", err)
+		return c.JSON(503, Response[any]{
+			Code: 503,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrPermissionCheckUnavailable",
+					Message: "Permission check is temporarily unavailable",
+				},
+			},
+		})
+	}
+
+	var responseDecision *Decision
+	if c.QueryParam("explain") == "true" {
+		responseDecision = toResponseDecision(decision)
+	}
+
+	if !decision.Allowed {
+		return c.JSON(403, Response[*Decision]{
 			Code: 403,
 			Errors: []shared.Errors{
 				{
@@ -127,11 +300,761 @@ func (h *Handler) CheckPermission(c *echo.Context) error {
 					Message: "Permission denied",
 				},
 			},
+			Data: responseDecision,
 		})
 	}
 
-	return c.JSON(200, Response[any]{
+	return c.JSON(200, Response[*Decision]{
 		Code:    200,
 		Message: "Permission is valid",
+		Data:    responseDecision,
+	})
+}
+
+func (h *Handler) CheckPermissions(c *echo.Context) error {
+	var request CommonRequest[CheckPermissionsRequest]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	items := make([]policies.PermissionCheckItem, 0, len(request.Data.Items))
+	for _, item := range request.Data.Items {
+		items = append(items, policies.PermissionCheckItem{
+			Resource: item.Resource,
+			Action:   policies.Action(item.Action),
+		})
+	}
+
+	requestContext := c.Request().Context()
+	decisions, err := h.service.CheckPermissions(requestContext, &policies.CheckPermissionsRequest{
+		AccountID:    request.Data.AccountID,
+		TeamMemberID: request.Data.TeamMemberID,
+		Items:        items,
+		Attributes:   shared.GetConditionAttributes(c, request.Data.Context),
+		AsOf:         request.Data.AsOf,
+	})
+	if err != nil {
+		if response, ok := getHistoryErrorResponse(err); ok {
+			return c.JSON(response.Code, response)
+		}
+		slog.ErrorContext(requestContext, "failed to check permissions", "error", err)
+		return c.JSON(503, Response[any]{
+			Code: 503,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrPermissionCheckUnavailable",
+					Message: "Permission check is temporarily unavailable",
+				},
+			},
+		})
+	}
+
+	isExplained := c.QueryParam("explain") == "true"
+	results := make([]PermissionCheckResult, 0, len(decisions))
+	for i, decision := range decisions {
+		result := PermissionCheckResult{
+			Resource: request.Data.Items[i].Resource,
+			Action:   request.Data.Items[i].Action,
+			Allowed:  decision.Allowed,
+		}
+		if isExplained {
+			result.Decision = toResponseDecision(decision)
+		}
+		results = append(results, result)
+	}
+
+	return c.JSON(200, Response[[]PermissionCheckResult]{
+		Code:    200,
+		Message: "Successfully checked permissions",
+		Data:    results,
+	})
+}
+
+// ListAccessibleResources answers which resources a member can perform an action on.
+// E.g., GET /v1/accounts/1/members/2/accessible-resources?action=write&prefix=funnels
+func (h *Handler) ListAccessibleResources(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+	teamMemberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	action := c.QueryParam("action")
+	if action == "" {
+		return c.JSON(400, Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrActionRequired",
+					Message: "Action query parameter is required",
+				},
+			},
+		})
+	}
+
+	requestContext := c.Request().Context()
+	accessibleResources, err := h.service.ListAccessibleResources(requestContext, &policies.ListAccessibleResourcesRequest{
+		AccountID:      accountID,
+		TeamMemberID:   teamMemberID,
+		Action:         policies.Action(action),
+		ResourcePrefix: c.QueryParam("prefix"),
+	})
+	if err != nil {
+		if errors.Is(err, policies.ErrInvalidResource) {
+			return c.JSON(400, Response[any]{
+				Code: 400,
+				Errors: []shared.Errors{
+					{
+						Code:    "ErrInvalidResource",
+						Message: "Prefix must be a path where * and ** only appear as whole segments",
+					},
+				},
+			})
+		}
+		slog.ErrorContext(requestContext, "failed to list accessible resources", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToListAccessibleResources",
+					Message: "Failed to list accessible resources",
+				},
+			},
+		})
+	}
+
+	return c.JSON(200, Response[*AccessibleResources]{
+		Code:    200,
+		Message: "Successfully listed accessible resources",
+		Data: &AccessibleResources{
+			Allowed: toResponseAccessibleResources(accessibleResources.Allowed),
+			Denied:  toResponseAccessibleResources(accessibleResources.Denied),
+		},
+	})
+}
+
+// ListAuthorizedMembers answers who can perform an action on a resource.
+// E.g., GET /v1/policies/authorized-members?account_id=1&resource=blogs/12/settings&action=write
+func (h *Handler) ListAuthorizedMembers(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.QueryParam("account_id"), 10, 64)
+	if err != nil {
+		return c.JSON(400, Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidAccountID",
+					Message: "account_id query parameter must be a number",
+				},
+			},
+		})
+	}
+
+	resource, action := c.QueryParam("resource"), c.QueryParam("action")
+	if resource == "" || action == "" {
+		return c.JSON(400, Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrResourceAndActionRequired",
+					Message: "resource and action query parameters are required",
+				},
+			},
+		})
+	}
+
+	requestContext := c.Request().Context()
+	members, err := h.service.ListAuthorizedMembers(requestContext, &policies.ListAuthorizedMembersRequest{
+		AccountID: accountID,
+		Resource:  resource,
+		Action:    policies.Action(action),
+	})
+	if err != nil {
+		if errors.Is(err, policies.ErrInvalidResource) {
+			return c.JSON(400, Response[any]{
+				Code: 400,
+				Errors: []shared.Errors{
+					{
+						Code:    "ErrInvalidResource",
+						Message: "Resource must be a non-empty path where * and ** only appear as whole segments",
+					},
+				},
+			})
+		}
+		slog.ErrorContext(requestContext, "failed to list authorized members", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToListAuthorizedMembers",
+					Message: "Failed to list authorized members",
+				},
+			},
+		})
+	}
+
+	responseMembers := make([]AuthorizedMember, 0, len(members))
+	for _, member := range members {
+		responseMembers = append(responseMembers, AuthorizedMember{
+			TeamMemberID: member.TeamMemberID,
+			Decision:     toResponseDecision(member.Decision),
+		})
+	}
+
+	return c.JSON(200, Response[[]AuthorizedMember]{
+		Code:    200,
+		Message: "Successfully listed authorized members",
+		Data:    responseMembers,
+	})
+}
+
+// GetEffectivePermissions answers what a member can do, per action.
+func (h *Handler) GetEffectivePermissions(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+	teamMemberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	requestContext := c.Request().Context()
+	permissions, err := h.service.GetEffectivePermissions(requestContext, &policies.GetEffectivePermissionsRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+	})
+	if err != nil {
+		slog.ErrorContext(requestContext, "failed to get effective permissions", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToGetEffectivePermissions",
+					Message: "Failed to get effective permissions",
+				},
+			},
+		})
+	}
+
+	responsePermissions := make([]ActionPermissions, 0, len(permissions))
+	for _, permission := range permissions {
+		responsePermissions = append(responsePermissions, ActionPermissions{
+			Action:  string(permission.Action),
+			Allowed: toResponseEffectiveGrants(permission.Allowed),
+			Denied:  toResponseEffectiveGrants(permission.Denied),
+		})
+	}
+
+	return c.JSON(200, Response[[]ActionPermissions]{
+		Code:    200,
+		Message: "Successfully retrieved effective permissions",
+		Data:    responsePermissions,
+	})
+}
+
+func toResponseEffectiveGrants(grants []policies.EffectiveGrant) []EffectiveGrant {
+	responseGrants := make([]EffectiveGrant, 0, len(grants))
+	for _, grant := range grants {
+		sources := make([]GrantSource, 0, len(grant.Sources))
+		for _, source := range grant.Sources {
+			sources = append(sources, GrantSource{
+				PolicyID: source.PolicyID,
+				GroupID:  source.GroupID,
+				RoleID:   source.RoleID,
+				Action:   string(source.Action),
+			})
+		}
+		responseGrants = append(responseGrants, EffectiveGrant{
+			Resource:  grant.Resource,
+			Condition: grant.Condition,
+			NotBefore: grant.NotBefore,
+			ExpiresAt: grant.ExpiresAt,
+			Sources:   sources,
+		})
+	}
+	return responseGrants
+}
+
+func toResponseAccessibleResources(resources []policies.AccessibleResource) []AccessibleResource {
+	responseResources := make([]AccessibleResource, 0, len(resources))
+	for _, resource := range resources {
+		responseResources = append(responseResources, AccessibleResource{
+			Resource:  resource.Resource,
+			Rule:      string(resource.Rule),
+			Condition: resource.Condition,
+			PolicyIDs: resource.PolicyIDs,
+			RoleIDs:   resource.RoleIDs,
+		})
+	}
+	return responseResources
+}
+
+func (h *Handler) invalidPathParamResponse(c *echo.Context) error {
+	return c.JSON(400, Response[any]{
+		Code: 400,
+		Errors: []shared.Errors{
+			{
+				Code:    "ErrInvalidPathParam",
+				Message: "Invalid path parameter",
+			},
+		},
+	})
+}
+
+// ExportPolicyDocument renders the member's own policies as an IAM-style document.
+// The document can be kept in version control and imported back as is.
+func (h *Handler) ExportPolicyDocument(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+	teamMemberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	requestContext := c.Request().Context()
+	document, err := h.service.ExportDocument(requestContext, &policies.ExportDocumentRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+	})
+	if err != nil {
+		slog.ErrorContext(requestContext, "failed to export policy document", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToExportPolicyDocument",
+					Message: "Failed to export policy document",
+				},
+			},
+		})
+	}
+
+	return c.JSON(200, Response[*policies.Document]{
+		Code:    200,
+		Message: "Successfully exported policy document",
+		Data:    document,
+	})
+}
+
+// ImportPolicyDocument creates the document's policies for the member.
+// Rejected policies do not fail the request; they are reported in the statement outcomes.
+func (h *Handler) ImportPolicyDocument(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+	teamMemberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	var request CommonRequest[json.RawMessage]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	document, err := policies.ParseDocument(request.Data)
+	if err != nil {
+		return c.JSON(400, Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidDocument",
+					Message: err.Error(),
+				},
+			},
+		})
+	}
+
+	requestContext := c.Request().Context()
+	outcomes, err := h.service.ImportDocument(requestContext, &policies.ImportDocumentRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+		Document:     document,
+	})
+	if err != nil {
+		slog.ErrorContext(requestContext, "failed to import policy document", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToImportPolicyDocument",
+					Message: "Failed to import policy document; no statement was applied",
+				},
+			},
+		})
+	}
+
+	responseOutcomes := make([]StatementOutcome, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		responseOutcomes = append(responseOutcomes, StatementOutcome{
+			Statement: outcome.Statement,
+			ID:        outcome.ID,
+			Policies:  toResponseImportedPolicies(outcome.Policies),
+		})
+	}
+
+	return c.JSON(200, Response[[]StatementOutcome]{
+		Code:    200,
+		Message: "Successfully imported policy document",
+		Data:    responseOutcomes,
+	})
+}
+
+// ParseRules validates rule text and returns the policies it compiles to. Nothing is saved.
+func (h *Handler) ParseRules(c *echo.Context) error {
+	var request CommonRequest[ParseRulesRequest]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	compiled, err := h.service.CompileRules(c.Request().Context(), request.Data.Text)
+	if err != nil {
+		var syntaxErr *policies.RuleSyntaxError
+		if errors.As(err, &syntaxErr) {
+			return c.JSON(400, Response[*RulePosition]{
+				Code: 400,
+				Errors: []shared.Errors{
+					{
+						Code:    "ErrInvalidRule",
+						Message: syntaxErr.Error(),
+					},
+				},
+				Data: &RulePosition{Line: syntaxErr.Line, Column: syntaxErr.Column},
+			})
+		}
+		if response, ok := getPolicyErrorResponse(err); ok {
+			response.Errors[0].Message = err.Error()
+			return c.JSON(response.Code, response)
+		}
+		slog.ErrorContext(c.Request().Context(), "failed to compile rules", "error", err)
+		return c.JSON(500, Response[any]{
+			Code:    500,
+			Message: "Failed to parse rules",
+		})
+	}
+
+	responsePolicies := make([]*Policy, 0, len(compiled.Policies))
+	for _, policy := range compiled.Policies {
+		responsePolicies = append(responsePolicies, fromDomainPolicy(&policy))
+	}
+
+	return c.JSON(200, Response[*ParseRulesResult]{
+		Code:    200,
+		Message: "Successfully parsed rules",
+		Data: &ParseRulesResult{
+			Formatted: policies.FormatRules(compiled.Rules),
+			Policies:  responsePolicies,
+		},
+	})
+}
+
+func toResponseImportedPolicies(importedPolicies []policies.ImportedPolicy) []ImportedPolicy {
+	responsePolicies := make([]ImportedPolicy, 0, len(importedPolicies))
+	for _, policy := range importedPolicies {
+		responsePolicy := ImportedPolicy{
+			Resource: policy.Resource,
+			Action:   string(policy.Action),
+			Status:   string(policy.Status),
+			PolicyID: policy.PolicyID,
+		}
+		if response, ok := getPolicyErrorResponse(policy.Err); ok {
+			responsePolicy.Error = (*Errors)(&response.Errors[0])
+		}
+		responsePolicies = append(responsePolicies, responsePolicy)
+	}
+	return responsePolicies
+}
+
+// ListRevisions lists the policy revisions of the account, or of one member when member_id is in the path.
+func (h *Handler) ListRevisions(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+	var teamMemberID int64
+	if memberID := c.Param("member_id"); memberID != "" {
+		if teamMemberID, err = strconv.ParseInt(memberID, 10, 64); err != nil {
+			return h.invalidPathParamResponse(c)
+		}
+	}
+
+	requestContext := c.Request().Context()
+	revisions, err := h.service.ListRevisions(requestContext, &policies.GetRevisionsRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+	})
+	if err != nil {
+		slog.ErrorContext(requestContext, "failed to list policy revisions", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToListRevisions",
+					Message: "Failed to list policy revisions",
+				},
+			},
+		})
+	}
+
+	responseRevisions := make([]Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		responseRevisions = append(responseRevisions, Revision{
+			ID:           revision.ID,
+			AccountID:    revision.AccountID,
+			TeamMemberID: revision.TeamMemberID,
+			GroupID:      revision.GroupID,
+			PolicyID:     revision.PolicyID,
+			Operation:    string(revision.Operation),
+			Actor:        revision.Actor,
+			Before:       fromDomainPolicy(revision.Before),
+			After:        fromDomainPolicy(revision.After),
+			CreatedAt:    revision.CreatedAt,
+		})
+	}
+
+	return c.JSON(200, Response[[]Revision]{
+		Code:    200,
+		Message: "Successfully retrieved policy revisions",
+		Data:    responseRevisions,
+	})
+}
+
+// Rollback restores the member's own policies to the state right after the revision.
+func (h *Handler) Rollback(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+	teamMemberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+	revisionID, err := strconv.ParseInt(c.Param("revision_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	requestContext := c.Request().Context()
+	result, err := h.service.Rollback(requestContext, &policies.RollbackRequest{
+		AccountID:    accountID,
+		TeamMemberID: teamMemberID,
+		RevisionID:   revisionID,
+	})
+	if err != nil {
+		if errors.Is(err, policies.ErrRevisionNotFound) {
+			return c.JSON(404, Response[any]{
+				Code: 404,
+				Errors: []shared.Errors{
+					{
+						Code:    "ErrRevisionNotFound",
+						Message: "Policy revision not found for the member",
+					},
+				},
+			})
+		}
+		slog.ErrorContext(requestContext, "failed to roll back policies", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToRollback",
+					Message: "Failed to roll back policies; no change was applied",
+				},
+			},
+		})
+	}
+
+	return c.JSON(200, Response[*RollbackResult]{
+		Code:    200,
+		Message: "Successfully rolled back policies",
+		Data: &RollbackResult{
+			DeletedPolicyIDs: result.DeletedPolicyIDs,
+			Created:          toResponseImportedPolicies(result.Created),
+		},
+	})
+}
+
+// LintPolicies reports problems with the account's policies. Sent as POST, it also fixes
+// redundant policies and unnormalized resources.
+func (h *Handler) LintPolicies(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	requestContext := c.Request().Context()
+	findings, err := h.service.LintPolicies(requestContext, &policies.LintPoliciesRequest{
+		AccountID: accountID,
+		Fix:       c.Request().Method == http.MethodPost,
+	})
+	if err != nil {
+		slog.ErrorContext(requestContext, "failed to lint policies", "error", err)
+		return c.JSON(500, Response[any]{
+			Code: 500,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrFailedToLintPolicies",
+					Message: "Failed to lint policies",
+				},
+			},
+		})
+	}
+
+	responseFindings := make([]LintFinding, 0, len(findings))
+	for _, finding := range findings {
+		responseFindings = append(responseFindings, LintFinding{
+			Kind:            string(finding.Kind),
+			PolicyID:        finding.PolicyID,
+			RelatedPolicyID: finding.RelatedPolicyID,
+			Message:         finding.Message,
+			IsFixable:       finding.IsFixable,
+			IsFixed:         finding.IsFixed,
+		})
+	}
+
+	return c.JSON(200, Response[[]LintFinding]{
+		Code:    200,
+		Message: "Successfully linted policies",
+		Data:    responseFindings,
+	})
+}
+
+// Simulate runs permission checks against hypothetical policy changes. Nothing is persisted.
+func (h *Handler) Simulate(c *echo.Context) error {
+	var request CommonRequest[SimulationRequest]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	added := make([]policies.Policy, 0, len(request.Data.Add))
+	for _, policy := range request.Data.Add {
+		added = append(added, toDomainPolicy(policy))
+	}
+
+	checks := make([]policies.CheckPermissionRequest, 0, len(request.Data.Checks))
+	for _, check := range request.Data.Checks {
+		checks = append(checks, policies.CheckPermissionRequest{
+			AccountID:    check.AccountID,
+			TeamMemberID: check.TeamMemberID,
+			Resource:     check.Resource,
+			Action:       policies.Action(check.Action),
+			Attributes:   shared.GetConditionAttributes(c, check.Context),
+		})
+	}
+
+	requestContext := c.Request().Context()
+	decisions, err := h.service.Simulate(requestContext, &policies.SimulationRequest{
+		Add:             added,
+		RemovePolicyIDs: request.Data.RemovePolicyIDs,
+		Checks:          checks,
+	})
+	if err != nil {
+		if response, ok := getPolicyErrorResponse(err); ok {
+			return c.JSON(response.Code, response)
+		}
+		slog.ErrorContext(requestContext, "failed to simulate policies", "error", err)
+		return c.JSON(503, Response[any]{
+			Code: 503,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrPermissionCheckUnavailable",
+					Message: "Permission check is temporarily unavailable",
+				},
+			},
+		})
+	}
+
+	results := make([]SimulationResult, 0, len(decisions))
+	for i, decision := range decisions {
+		results = append(results, SimulationResult{
+			TeamMemberID: request.Data.Checks[i].TeamMemberID,
+			Resource:     request.Data.Checks[i].Resource,
+			Action:       request.Data.Checks[i].Action,
+			Decision:     toResponseDecision(decision),
+		})
+	}
+
+	return c.JSON(200, Response[[]SimulationResult]{
+		Code:    200,
+		Message: "Successfully simulated policies",
+		Data:    results,
+	})
+}
+
+// getHistoryErrorResponse maps point-in-time checks the recorded history cannot answer to client error responses.
+func getHistoryErrorResponse(err error) (Response[any], bool) {
+	if errors.Is(err, policies.ErrHistoryUnavailable) {
+		return Response[any]{
+			Code: 422,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrHistoryUnavailable",
+					Message: "Policy history is not recorded as far back as as_of",
+				},
+			},
+		}, true
+	}
+	if errors.Is(err, policies.ErrHistoryIncomplete) {
+		return Response[any]{
+			Code: 422,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrHistoryIncomplete",
+					Message: "Decision depends on roles or group memberships, which have no history",
+				},
+			},
+		}, true
+	}
+
+	return Response[any]{}, false
+}
+
+func toResponseDecision(decision *policies.Decision) *Decision {
+	return &Decision{
+		Allowed:   decision.Allowed,
+		PolicyIDs: decision.PolicyIDs,
+		RoleIDs:   decision.RoleIDs,
+		Rule:      string(decision.Rule),
+		Reason:    string(decision.Reason),
+	}
+}
+
+func (h *Handler) ListResourceTemplates(c *echo.Context) error {
+	templates := h.service.ListResourceTemplates(c.Request().Context())
+
+	responseTemplates := make([]ResourceTemplate, 0, len(templates))
+	for _, template := range templates {
+		actions := make([]string, 0, len(template.Actions))
+		for _, action := range template.Actions {
+			actions = append(actions, string(action))
+		}
+		responseTemplates = append(responseTemplates, ResourceTemplate{
+			Template: template.Template,
+			Actions:  actions,
+		})
+	}
+
+	return c.JSON(200, Response[[]ResourceTemplate]{
+		Code:    200,
+		Message: "Successfully listed resource templates",
+		Data:    responseTemplates,
 	})
 }
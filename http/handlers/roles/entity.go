@@ -0,0 +1,31 @@
+package handlersroles
+
+import "github.com/adhikag24/policy-based-permission-model/http/handlers/shared"
+
+type Role struct {
+	ID            int64       `json:"id"`
+	AccountID     int64       `json:"account_id"`
+	Name          string      `json:"name"`
+	Statements    []Statement `json:"statements"`
+	ParentRoleIDs []int64     `json:"parent_role_ids,omitempty"`
+}
+
+type Statement struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Effect   string `json:"effect,omitempty"` // allow (default) or deny.
+}
+
+type EffectiveStatement struct {
+	Statement
+	RoleID int64 `json:"role_id"`
+}
+
+type AssignRoleRequest struct {
+	TeamMemberID int64 `json:"team_member_id"`
+}
+
+type (
+	CommonRequest[T any] shared.CommonRequest[T]
+	Response[T any]      shared.Response[T]
+)
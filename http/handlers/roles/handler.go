@@ -0,0 +1,325 @@
+package handlersroles
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/adhikag24/policy-based-permission-model/domain/roles"
+	"github.com/adhikag24/policy-based-permission-model/http/handlers/shared"
+	"github.com/labstack/echo/v5"
+)
+
+type Handler struct {
+	service roles.Service
+}
+
+func NewHandler(service roles.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) CreateRole(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	var request CommonRequest[Role]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	role := toDomainRole(request.Data)
+	role.AccountID = accountID
+	createdRole, err := h.service.CreateRole(c.Request().Context(), &role)
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToCreateRole", "Failed to create role")
+	}
+
+	return c.JSON(201, Response[*Role]{
+		Code:    201,
+		Message: "Successfully created role",
+		Data:    fromDomainRole(createdRole),
+	})
+}
+
+func (h *Handler) UpdateRole(c *echo.Context) error {
+	accountID, roleID, err := h.getRolePathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	var request CommonRequest[Role]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	role := toDomainRole(request.Data)
+	role.ID = roleID
+	role.AccountID = accountID
+	updatedRole, err := h.service.UpdateRole(c.Request().Context(), &role)
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToUpdateRole", "Failed to update role")
+	}
+
+	return c.JSON(200, Response[*Role]{
+		Code:    200,
+		Message: "Successfully updated role",
+		Data:    fromDomainRole(updatedRole),
+	})
+}
+
+func (h *Handler) DeleteRole(c *echo.Context) error {
+	accountID, roleID, err := h.getRolePathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	err = h.service.DeleteRole(c.Request().Context(), &roles.GetRoleRequest{
+		AccountID: accountID,
+		RoleID:    roleID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToDeleteRole", "Failed to delete role")
+	}
+
+	return c.JSON(200, Response[any]{
+		Code:    200,
+		Message: "Successfully deleted role",
+	})
+}
+
+func (h *Handler) GetRole(c *echo.Context) error {
+	accountID, roleID, err := h.getRolePathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	role, err := h.service.GetRole(c.Request().Context(), &roles.GetRoleRequest{
+		AccountID: accountID,
+		RoleID:    roleID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToRetrieveRole", "Failed to retrieve role")
+	}
+
+	return c.JSON(200, Response[*Role]{
+		Code:    200,
+		Message: "Successfully retrieved role",
+		Data:    fromDomainRole(role),
+	})
+}
+
+func (h *Handler) ListRoles(c *echo.Context) error {
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	domainRoles, err := h.service.ListRoles(c.Request().Context(), accountID)
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToListRoles", "Failed to list roles")
+	}
+
+	responseRoles := make([]*Role, 0, len(domainRoles))
+	for i := range domainRoles {
+		responseRoles = append(responseRoles, fromDomainRole(&domainRoles[i]))
+	}
+
+	return c.JSON(200, Response[[]*Role]{
+		Code:    200,
+		Message: "Successfully listed roles",
+		Data:    responseRoles,
+	})
+}
+
+func (h *Handler) FlattenRole(c *echo.Context) error {
+	accountID, roleID, err := h.getRolePathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	statements, err := h.service.FlattenRole(c.Request().Context(), &roles.GetRoleRequest{
+		AccountID: accountID,
+		RoleID:    roleID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToFlattenRole", "Failed to flatten role")
+	}
+
+	responseStatements := make([]EffectiveStatement, 0, len(statements))
+	for _, statement := range statements {
+		responseStatements = append(responseStatements, EffectiveStatement{
+			Statement: Statement{
+				Resource: statement.Resource,
+				Action:   string(statement.Action),
+				Effect:   string(statement.Effect),
+			},
+			RoleID: statement.RoleID,
+		})
+	}
+
+	return c.JSON(200, Response[[]EffectiveStatement]{
+		Code:    200,
+		Message: "Successfully flattened role",
+		Data:    responseStatements,
+	})
+}
+
+func (h *Handler) AssignRole(c *echo.Context) error {
+	accountID, roleID, err := h.getRolePathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	var request CommonRequest[AssignRoleRequest]
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(400, Response[any]{
+			Code:    400,
+			Message: "Invalid request payload",
+		})
+	}
+
+	err = h.service.AssignRole(c.Request().Context(), &roles.Assignment{
+		AccountID:    accountID,
+		RoleID:       roleID,
+		TeamMemberID: request.Data.TeamMemberID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToAssignRole", "Failed to assign role")
+	}
+
+	return c.JSON(201, Response[any]{
+		Code:    201,
+		Message: "Successfully assigned role",
+	})
+}
+
+func (h *Handler) UnassignRole(c *echo.Context) error {
+	accountID, roleID, err := h.getRolePathParams(c)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	teamMemberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return h.invalidPathParamResponse(c)
+	}
+
+	err = h.service.UnassignRole(c.Request().Context(), &roles.Assignment{
+		AccountID:    accountID,
+		RoleID:       roleID,
+		TeamMemberID: teamMemberID,
+	})
+	if err != nil {
+		return h.handleErrorResponse(c, err, "ErrFailedToUnassignRole", "Failed to unassign role")
+	}
+
+	return c.JSON(200, Response[any]{
+		Code:    200,
+		Message: "Successfully unassigned role",
+	})
+}
+
+func (h *Handler) handleErrorResponse(c *echo.Context, err error, genericErrorCode, genericErrorMessage string) error {
+	switch {
+	case errors.Is(err, roles.ErrRoleNotFound):
+		return c.JSON(404, Response[any]{
+			Code: 404,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrRoleNotFound",
+					Message: "Role not found",
+				},
+			},
+		})
+	case errors.Is(err, roles.ErrRoleNameRequired), errors.Is(err, roles.ErrInvalidStatement),
+		errors.Is(err, roles.ErrInheritanceCycle), errors.Is(err, roles.ErrNotAccountMember):
+		return c.JSON(400, Response[any]{
+			Code: 400,
+			Errors: []shared.Errors{
+				{
+					Code:    "ErrInvalidRole",
+					Message: err.Error(),
+				},
+			},
+		})
+	}
+
+	return c.JSON(500, Response[any]{
+		Code: 500,
+		Errors: []shared.Errors{
+			{
+				Code:    genericErrorCode,
+				Message: genericErrorMessage,
+			},
+		},
+	})
+}
+
+func (h *Handler) invalidPathParamResponse(c *echo.Context) error {
+	return c.JSON(400, Response[any]{
+		Code: 400,
+		Errors: []shared.Errors{
+			{
+				Code:    "ErrInvalidPathParam",
+				Message: "Invalid path parameter",
+			},
+		},
+	})
+}
+
+func (h *Handler) getRolePathParams(c *echo.Context) (accountID int64, roleID int64, err error) {
+	accountID, err = strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	roleID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return accountID, roleID, nil
+}
+
+func toDomainRole(role Role) roles.Role {
+	domainRole := roles.Role{
+		ID:            role.ID,
+		AccountID:     role.AccountID,
+		Name:          role.Name,
+		ParentRoleIDs: role.ParentRoleIDs,
+	}
+	for _, statement := range role.Statements {
+		domainRole.Statements = append(domainRole.Statements, roles.Statement{
+			Resource: statement.Resource,
+			Action:   policies.Action(statement.Action),
+			Effect:   policies.Effect(statement.Effect),
+		})
+	}
+	return domainRole
+}
+
+func fromDomainRole(role *roles.Role) *Role {
+	responseRole := &Role{
+		ID:            role.ID,
+		AccountID:     role.AccountID,
+		Name:          role.Name,
+		Statements:    []Statement{},
+		ParentRoleIDs: role.ParentRoleIDs,
+	}
+	for _, statement := range role.Statements {
+		responseRole.Statements = append(responseRole.Statements, Statement{
+			Resource: statement.Resource,
+			Action:   string(statement.Action),
+			Effect:   string(statement.Effect),
+		})
+	}
+	return responseRole
+}
@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"maps"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/labstack/echo/v5"
+)
+
+// GetConditionAttributes returns the attributes policy conditions are evaluated against. Client values
+// for built-in attributes are ignored: ip is the address echo's IPExtractor resolves, and time is set
+// by the policies service.
+func GetConditionAttributes(c *echo.Context, attributes map[string]string) map[string]string {
+	attributes = maps.Clone(attributes)
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	delete(attributes, policies.AttributeTime)
+	attributes[policies.AttributeIP] = c.RealIP()
+
+	return attributes
+}
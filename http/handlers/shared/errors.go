@@ -0,0 +1,25 @@
+package shared
+
+import (
+	"errors"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+)
+
+// GetPermissionCheckErrorResponse maps a permission check that could not be made to 503.
+// The permission could not be checked, which is not the same as being denied.
+func GetPermissionCheckErrorResponse(err error) (Response[any], bool) {
+	if !errors.Is(err, policies.ErrPermissionCheckUnavailable) {
+		return Response[any]{}, false
+	}
+
+	return Response[any]{
+		Code: 503,
+		Errors: []Errors{
+			{
+				Code:    "ErrPermissionCheckUnavailable",
+				Message: "Permission check is temporarily unavailable",
+			},
+		},
+	}, true
+}
@@ -10,12 +10,43 @@ func RegisterRoutes(e *echo.Echo, h *Handlers) {
 	api.POST("/v1/policies", h.Policies.CreatePolicy)
 	api.DELETE("/v1/policies/:id", h.Policies.DeletePolicy)
 	api.POST("/v1/policies/check-permission", h.Policies.CheckPermission)
+	api.POST("/v1/policies/check-permissions", h.Policies.CheckPermissions)
+	api.POST("/v1/policies/simulate", h.Policies.Simulate)
+	api.POST("/v1/policies/parse", h.Policies.ParseRules)
+	api.GET("/v1/policies/resource-schema", h.Policies.ListResourceTemplates)
+	api.GET("/v1/policies/authorized-members", h.Policies.ListAuthorizedMembers)
+	api.GET("/v1/accounts/:account_id/members/:member_id/accessible-resources", h.Policies.ListAccessibleResources)
+	api.GET("/v1/accounts/:account_id/members/:member_id/permissions", h.Policies.GetEffectivePermissions)
+	api.GET("/v1/accounts/:account_id/members/:member_id/policy-document", h.Policies.ExportPolicyDocument)
+	api.POST("/v1/accounts/:account_id/members/:member_id/policy-document", h.Policies.ImportPolicyDocument)
+	api.GET("/v1/accounts/:account_id/policy-lint", h.Policies.LintPolicies)
+	api.POST("/v1/accounts/:account_id/policy-lint", h.Policies.LintPolicies)
+	api.GET("/v1/accounts/:account_id/policy-revisions", h.Policies.ListRevisions)
+	api.GET("/v1/accounts/:account_id/members/:member_id/policy-revisions", h.Policies.ListRevisions)
+	api.POST("/v1/accounts/:account_id/members/:member_id/policy-revisions/:revision_id/rollback", h.Policies.Rollback)
+
+	api.POST("/v1/accounts/:account_id/roles", h.Roles.CreateRole)
+	api.GET("/v1/accounts/:account_id/roles", h.Roles.ListRoles)
+	api.GET("/v1/accounts/:account_id/roles/:id", h.Roles.GetRole)
+	api.PUT("/v1/accounts/:account_id/roles/:id", h.Roles.UpdateRole)
+	api.DELETE("/v1/accounts/:account_id/roles/:id", h.Roles.DeleteRole)
+	api.GET("/v1/accounts/:account_id/roles/:id/flatten", h.Roles.FlattenRole)
+	api.POST("/v1/accounts/:account_id/roles/:id/assignments", h.Roles.AssignRole)
+	api.DELETE("/v1/accounts/:account_id/roles/:id/assignments/:member_id", h.Roles.UnassignRole)
+
+	api.POST("/v1/accounts/:account_id/groups", h.Groups.CreateGroup)
+	api.GET("/v1/accounts/:account_id/groups", h.Groups.ListGroups)
+	api.DELETE("/v1/accounts/:account_id/groups/:id", h.Groups.DeleteGroup)
+	api.POST("/v1/accounts/:account_id/groups/:id/members", h.Groups.AddMember)
+	api.DELETE("/v1/accounts/:account_id/groups/:id/members/:member_id", h.Groups.RemoveMember)
 
 	api.POST("/v1/funnels", h.Funnels.CreateFunnel)
 	api.GET("/v1/funnels/:id", h.Funnels.GetFunnel)
+	api.DELETE("/v1/funnels/:id", h.Funnels.DeleteFunnel)
 
 	api.POST("/v1/blogs/pages", h.Blogs.WriteBlogPage)
 	api.GET("/v1/blogs/pages", h.Blogs.ReadBlogPage)
+	api.POST("/v1/blogs/pages/publish", h.Blogs.PublishBlogPage)
 	api.POST("/v1/blogs/settings", h.Blogs.WriteBlogSettings)
 	api.GET("/v1/blogs/settings/:id", h.Blogs.ReadBlogSettings)
 
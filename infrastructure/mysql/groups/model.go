@@ -0,0 +1,58 @@
+package mysqlgroups
+
+import (
+	"time"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/groups"
+)
+
+type GroupModel struct {
+	ID        int64 `gorm:"primaryKey"`
+	AccountID int64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GROUPS is a reserved word in MySQL 8, hence the account_ prefix.
+func (GroupModel) TableName() string {
+	return "account_groups"
+}
+
+type GroupMemberModel struct {
+	ID           int64 `gorm:"primaryKey"`
+	AccountID    int64
+	GroupID      int64
+	TeamMemberID int64
+	CreatedAt    time.Time
+}
+
+func (GroupMemberModel) TableName() string {
+	return "account_group_members"
+}
+
+type AccountTeamMemberModel struct {
+	ID           int64 `gorm:"primaryKey"`
+	AccountID    int64
+	TeamMemberID int64
+}
+
+func (AccountTeamMemberModel) TableName() string {
+	return "account_team_members"
+}
+
+func ToDomain(m GroupModel) groups.Group {
+	return groups.Group{
+		ID:        m.ID,
+		AccountID: m.AccountID,
+		Name:      m.Name,
+	}
+}
+
+func FromDomain(g groups.Group) GroupModel {
+	return GroupModel{
+		ID:        g.ID,
+		AccountID: g.AccountID,
+		Name:      g.Name,
+	}
+}
@@ -0,0 +1,113 @@
+package mysqlgroups
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/groups"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, group *groups.Group) (*groups.Group, error) {
+	groupModel := FromDomain(*group)
+	if err := r.db.WithContext(ctx).Create(&groupModel).Error; err != nil {
+		return nil, err
+	}
+	response := ToDomain(groupModel)
+	return &response, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, request *groups.GetGroupRequest) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("account_id = ? AND group_id = ?", request.AccountID, request.GroupID).Delete(&GroupMemberModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND account_id = ?", request.GroupID, request.AccountID).Delete(&GroupModel{}).Error
+	})
+}
+
+func (r *Repository) Get(ctx context.Context, request *groups.GetGroupRequest) (*groups.Group, error) {
+	var groupModel GroupModel
+	err := r.db.WithContext(ctx).Where("id = ? AND account_id = ?", request.GroupID, request.AccountID).
+		First(&groupModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, groups.ErrGroupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := ToDomain(groupModel)
+	return &response, nil
+}
+
+func (r *Repository) List(ctx context.Context, accountID int64) ([]groups.Group, error) {
+	var groupModels []GroupModel
+	if err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Find(&groupModels).Error; err != nil {
+		return nil, err
+	}
+	var response []groups.Group
+	for _, gm := range groupModels {
+		response = append(response, ToDomain(gm))
+	}
+	return response, nil
+}
+
+func (r *Repository) AddMember(ctx context.Context, membership *groups.Membership) error {
+	return r.db.WithContext(ctx).Create(&GroupMemberModel{
+		AccountID:    membership.AccountID,
+		GroupID:      membership.GroupID,
+		TeamMemberID: membership.TeamMemberID,
+	}).Error
+}
+
+func (r *Repository) RemoveMember(ctx context.Context, membership *groups.Membership) error {
+	return r.db.WithContext(ctx).Where("account_id = ? AND group_id = ? AND team_member_id = ?",
+		membership.AccountID, membership.GroupID, membership.TeamMemberID).Delete(&GroupMemberModel{}).Error
+}
+
+func (r *Repository) GetByTeamMember(ctx context.Context, request *groups.GetByTeamMemberRequest) ([]groups.Group, error) {
+	var groupModels []GroupModel
+	err := r.db.WithContext(ctx).
+		Joins("JOIN account_group_members ON account_group_members.group_id = account_groups.id").
+		Where("account_group_members.account_id = ? AND account_group_members.team_member_id = ?",
+			request.AccountID, request.TeamMemberID).
+		Find(&groupModels).Error
+	if err != nil {
+		return nil, err
+	}
+	var response []groups.Group
+	for _, gm := range groupModels {
+		response = append(response, ToDomain(gm))
+	}
+	return response, nil
+}
+
+func (r *Repository) IsAccountMember(ctx context.Context, request *groups.GetByTeamMemberRequest) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&AccountTeamMemberModel{}).
+		Where("account_id = ? AND team_member_id = ?", request.AccountID, request.TeamMemberID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) GetMemberIDs(ctx context.Context, request *groups.GetGroupRequest) ([]int64, error) {
+	var teamMemberIDs []int64
+	err := r.db.WithContext(ctx).Model(&GroupMemberModel{}).
+		Where("account_id = ? AND group_id = ?", request.AccountID, request.GroupID).
+		Pluck("team_member_id", &teamMemberIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return teamMemberIDs, nil
+}
@@ -1,6 +1,7 @@
 package mysqlpolicies
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/adhikag24/policy-based-permission-model/domain/policies"
@@ -10,8 +11,13 @@ type PolicyModel struct {
 	ID           int64 `gorm:"primaryKey"`
 	AccountID    int64
 	TeamMemberID int64
+	GroupID      int64
 	Resource     string
 	Action       string
+	Effect       string
+	Condition    string `gorm:"column:condition_expr"` // CONDITION is a reserved word in MySQL.
+	NotBefore    *time.Time
+	ExpiresAt    *time.Time
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -25,8 +31,13 @@ func ToDomain(m PolicyModel) policies.Policy {
 		ID:           m.ID,
 		AccountID:    m.AccountID,
 		TeamMemberID: m.TeamMemberID,
+		GroupID:      m.GroupID,
 		Resource:     m.Resource,
 		Action:       policies.Action(m.Action),
+		Effect:       policies.Effect(m.Effect),
+		Condition:    m.Condition,
+		NotBefore:    m.NotBefore,
+		ExpiresAt:    m.ExpiresAt,
 	}
 }
 
@@ -35,7 +46,140 @@ func FromDomain(p policies.Policy) PolicyModel {
 		ID:           p.ID,
 		AccountID:    p.AccountID,
 		TeamMemberID: p.TeamMemberID,
+		GroupID:      p.GroupID,
 		Resource:     p.Resource,
 		Action:       string(p.Action),
+		Effect:       string(p.Effect),
+		Condition:    p.Condition,
+		NotBefore:    p.NotBefore,
+		ExpiresAt:    p.ExpiresAt,
 	}
 }
+
+type PolicyRevisionModel struct {
+	ID           int64 `gorm:"primaryKey"`
+	AccountID    int64
+	TeamMemberID int64
+	GroupID      int64
+	PolicyID     int64
+	Operation    string
+	Actor        string
+	BeforeState  *string // JSON encoded policyState, nil when created.
+	AfterState   *string // JSON encoded policyState, nil when deleted.
+	CreatedAt    time.Time
+}
+
+func (PolicyRevisionModel) TableName() string {
+	return "policy_revisions"
+}
+
+// policyState is the snapshot of a policy stored in a revision.
+type policyState struct {
+	ID           int64      `json:"id"`
+	AccountID    int64      `json:"account_id"`
+	TeamMemberID int64      `json:"team_member_id"`
+	GroupID      int64      `json:"group_id"`
+	Resource     string     `json:"resource"`
+	Action       string     `json:"action"`
+	Effect       string     `json:"effect"`
+	Condition    string     `json:"condition"`
+	NotBefore    *time.Time `json:"not_before"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+}
+
+func NewRevisionModel(operation policies.RevisionOperation, actor string, before, after *PolicyModel) (PolicyRevisionModel, error) {
+	state := before
+	if state == nil {
+		state = after
+	}
+
+	revisionModel := PolicyRevisionModel{
+		AccountID:    state.AccountID,
+		TeamMemberID: state.TeamMemberID,
+		GroupID:      state.GroupID,
+		PolicyID:     state.ID,
+		Operation:    string(operation),
+		Actor:        actor,
+	}
+
+	var err error
+	if revisionModel.BeforeState, err = encodePolicyState(before); err != nil {
+		return PolicyRevisionModel{}, err
+	}
+	if revisionModel.AfterState, err = encodePolicyState(after); err != nil {
+		return PolicyRevisionModel{}, err
+	}
+	return revisionModel, nil
+}
+
+func RevisionToDomain(m PolicyRevisionModel) (policies.Revision, error) {
+	revision := policies.Revision{
+		ID:           m.ID,
+		AccountID:    m.AccountID,
+		TeamMemberID: m.TeamMemberID,
+		GroupID:      m.GroupID,
+		PolicyID:     m.PolicyID,
+		Operation:    policies.RevisionOperation(m.Operation),
+		Actor:        m.Actor,
+		CreatedAt:    m.CreatedAt,
+	}
+
+	var err error
+	if revision.Before, err = decodePolicyState(m.BeforeState); err != nil {
+		return policies.Revision{}, err
+	}
+	if revision.After, err = decodePolicyState(m.AfterState); err != nil {
+		return policies.Revision{}, err
+	}
+	return revision, nil
+}
+
+func encodePolicyState(m *PolicyModel) (*string, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(policyState{
+		ID:           m.ID,
+		AccountID:    m.AccountID,
+		TeamMemberID: m.TeamMemberID,
+		GroupID:      m.GroupID,
+		Resource:     m.Resource,
+		Action:       m.Action,
+		Effect:       m.Effect,
+		Condition:    m.Condition,
+		NotBefore:    m.NotBefore,
+		ExpiresAt:    m.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := string(encoded)
+	return &state, nil
+}
+
+func decodePolicyState(encoded *string) (*policies.Policy, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+
+	var state policyState
+	if err := json.Unmarshal([]byte(*encoded), &state); err != nil {
+		return nil, err
+	}
+
+	policy := ToDomain(PolicyModel{
+		ID:           state.ID,
+		AccountID:    state.AccountID,
+		TeamMemberID: state.TeamMemberID,
+		GroupID:      state.GroupID,
+		Resource:     state.Resource,
+		Action:       state.Action,
+		Effect:       state.Effect,
+		Condition:    state.Condition,
+		NotBefore:    state.NotBefore,
+		ExpiresAt:    state.ExpiresAt,
+	})
+	return &policy, nil
+}
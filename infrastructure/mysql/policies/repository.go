@@ -2,10 +2,13 @@ package mysqlpolicies
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/adhikag24/policy-based-permission-model/domain/policies"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository struct {
@@ -18,25 +21,107 @@ func NewRepository(db *gorm.DB) *Repository {
 
 func (r *Repository) Create(ctx context.Context, policy *policies.Policy) (*policies.Policy, error) {
 	policyModel := FromDomain(*policy)
-	if err := r.db.WithContext(ctx).Create(&policyModel).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&policyModel).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, tx, policies.RevisionOperationCreated, nil, &policyModel)
+	})
+	if err != nil {
 		return nil, err
 	}
 	response := ToDomain(policyModel)
 	return &response, nil
 }
 
+// Transaction nests the transactions of the repository methods as savepoints.
+func (r *Repository) Transaction(ctx context.Context, fn func(repo policies.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{db: tx})
+	})
+}
+
 func (r *Repository) Delete(ctx context.Context, policyID string) error {
-	if err := r.db.WithContext(ctx).Delete(&PolicyModel{}, policyID).Error; err != nil {
-		return err
-	}
-	return nil
+	_, err := r.deleteWhere(ctx, r.db.Where("id = ?", policyID))
+	return err
 }
 
-// Retreives list of policies based on account ID, team member ID, and action.
+// Retreives list of policies based on account ID, principal (team member or groups), and actions.
 func (r *Repository) Get(ctx context.Context, request *policies.GetPolicyRequest) ([]policies.Policy, error) {
+	principal := r.db.Where("1 = 0")
+	if request.TeamMemberID != 0 {
+		principal = principal.Or("team_member_id = ?", request.TeamMemberID)
+	}
+	if len(request.GroupIDs) > 0 {
+		principal = principal.Or("group_id IN ?", request.GroupIDs)
+	}
+
+	var policyModels []PolicyModel
+	err := r.db.WithContext(ctx).Where("account_id = ? AND action IN ?", request.AccountID, request.Actions).
+		Where(principal).Find(&policyModels).Error
+	if err != nil {
+		return nil, err
+	}
+	var policies []policies.Policy
+	for _, pm := range policyModels {
+		policies = append(policies, ToDomain(pm))
+	}
+	return policies, nil
+}
+
+// likeEscaper escapes LIKE wildcards so a prefix such as blog_posts/ only matches itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// DeleteExpired removes policies whose validity window ended at or before the given time.
+func (r *Repository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return r.deleteWhere(ctx, r.db.Where("expires_at IS NOT NULL AND expires_at <= ?", before))
+}
+
+// deleteWhere locks the matching rows to record what they were before deleting them.
+func (r *Repository) deleteWhere(ctx context.Context, condition *gorm.DB) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var policyModels []PolicyModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(condition).Find(&policyModels).Error; err != nil {
+			return err
+		}
+		if len(policyModels) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(policyModels))
+		for _, pm := range policyModels {
+			ids = append(ids, pm.ID)
+		}
+		result := tx.Where("id IN ?", ids).Delete(&PolicyModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+
+		for _, pm := range policyModels {
+			if err := r.recordRevision(ctx, tx, policies.RevisionOperationDeleted, &pm, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// GetByResource cannot match glob patterns in SQL, so every wildcard policy of the account is a candidate.
+func (r *Repository) GetByResource(ctx context.Context, request *policies.GetByResourceRequest) ([]policies.Policy, error) {
+	resource := r.db.Where("resource = ?", request.Resource).Or("resource LIKE ?", "%*%")
+	if request.IncludeDescendants {
+		resource = resource.Or("resource LIKE ?", likeEscaper.Replace(request.Resource)+"/%")
+	}
+
 	var policyModels []PolicyModel
-	err := r.db.WithContext(ctx).Where("account_id = ? AND team_member_id = ? AND action = ?",
-		request.AccountID, request.TeamMemberID, string(request.Action)).Find(&policyModels).Error
+	err := r.db.WithContext(ctx).Where("account_id = ? AND action IN ?", request.AccountID, request.Actions).
+		Where(resource).Find(&policyModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -47,12 +132,80 @@ func (r *Repository) Get(ctx context.Context, request *policies.GetPolicyRequest
 	return policies, nil
 }
 
-func (r *Repository) DeleteByPrefix(ctx context.Context, request *policies.DeleteByPrefixRequest) error {
-	prefixLike := fmt.Sprintf("%s%%", request.ResourcePrefix)
-	err := r.db.WithContext(ctx).Where("account_id = ? AND team_member_id = ? AND resource LIKE ? AND action = ?",
-		request.AccountID, request.TeamMemberID, prefixLike, string(request.Action)).Delete(&PolicyModel{}).Error
+func (r *Repository) GetByAccount(ctx context.Context, accountID int64) ([]policies.Policy, error) {
+	var policyModels []PolicyModel
+	if err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("id").Find(&policyModels).Error; err != nil {
+		return nil, err
+	}
+	var policies []policies.Policy
+	for _, pm := range policyModels {
+		policies = append(policies, ToDomain(pm))
+	}
+	return policies, nil
+}
+
+func (r *Repository) UpdateResource(ctx context.Context, policyID int64, resource string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before PolicyModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, policyID).Error; err != nil {
+			return err
+		}
+
+		after := before
+		after.Resource = resource
+		if err := tx.Model(&PolicyModel{}).Where("id = ?", policyID).Update("resource", resource).Error; err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, tx, policies.RevisionOperationUpdated, &before, &after)
+	})
+}
+
+func (r *Repository) recordRevision(ctx context.Context, tx *gorm.DB, operation policies.RevisionOperation, before, after *PolicyModel) error {
+	revisionModel, err := NewRevisionModel(operation, policies.ActorFromContext(ctx), before, after)
 	if err != nil {
 		return err
 	}
-	return nil
+	return tx.Create(&revisionModel).Error
+}
+
+func (r *Repository) GetRevisions(ctx context.Context, request *policies.GetRevisionsRequest) ([]policies.Revision, error) {
+	query := r.db.WithContext(ctx).Where("account_id = ? AND id >= ?", request.AccountID, request.FromRevisionID)
+	switch {
+	case request.TeamMemberID != 0 && len(request.GroupIDs) > 0:
+		query = query.Where("team_member_id = ? OR group_id IN ?", request.TeamMemberID, request.GroupIDs)
+	case request.TeamMemberID != 0:
+		query = query.Where("team_member_id = ?", request.TeamMemberID)
+	case len(request.GroupIDs) > 0:
+		query = query.Where("group_id IN ?", request.GroupIDs)
+	}
+	if !request.After.IsZero() {
+		query = query.Where("created_at > ?", request.After)
+	}
+
+	var revisionModels []PolicyRevisionModel
+	if err := query.Order("id").Find(&revisionModels).Error; err != nil {
+		return nil, err
+	}
+	var revisions []policies.Revision
+	for _, rm := range revisionModels {
+		revision, err := RevisionToDomain(rm)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+func (r *Repository) GetRevisionsStart(ctx context.Context) (time.Time, error) {
+	// Revisions are append only, so the lowest ID is the first one recorded.
+	var model PolicyRevisionModel
+	err := r.db.WithContext(ctx).Order("id").Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return model.CreatedAt, nil
 }
@@ -0,0 +1,108 @@
+package mysqlroles
+
+import (
+	"time"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/policies"
+	"github.com/adhikag24/policy-based-permission-model/domain/roles"
+)
+
+type RoleModel struct {
+	ID          int64 `gorm:"primaryKey"`
+	AccountID   int64
+	Name        string
+	Statements  []RoleStatementModel `gorm:"foreignKey:RoleID"`
+	ParentLinks []RoleParentModel    `gorm:"foreignKey:RoleID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (RoleModel) TableName() string {
+	return "roles"
+}
+
+type RoleStatementModel struct {
+	ID       int64 `gorm:"primaryKey"`
+	RoleID   int64
+	Resource string
+	Action   string
+	Effect   string
+}
+
+func (RoleStatementModel) TableName() string {
+	return "role_statements"
+}
+
+type RoleParentModel struct {
+	ID           int64 `gorm:"primaryKey"`
+	RoleID       int64
+	ParentRoleID int64
+}
+
+func (RoleParentModel) TableName() string {
+	return "role_parents"
+}
+
+type RoleAssignmentModel struct {
+	ID           int64 `gorm:"primaryKey"`
+	AccountID    int64
+	RoleID       int64
+	TeamMemberID int64
+	CreatedAt    time.Time
+}
+
+func (RoleAssignmentModel) TableName() string {
+	return "role_assignments"
+}
+
+type AccountTeamMemberModel struct {
+	ID           int64 `gorm:"primaryKey"`
+	AccountID    int64
+	TeamMemberID int64
+}
+
+func (AccountTeamMemberModel) TableName() string {
+	return "account_team_members"
+}
+
+func ToDomain(m RoleModel) roles.Role {
+	role := roles.Role{
+		ID:        m.ID,
+		AccountID: m.AccountID,
+		Name:      m.Name,
+	}
+	for _, sm := range m.Statements {
+		role.Statements = append(role.Statements, roles.Statement{
+			Resource: sm.Resource,
+			Action:   policies.Action(sm.Action),
+			Effect:   policies.Effect(sm.Effect),
+		})
+	}
+	for _, pm := range m.ParentLinks {
+		role.ParentRoleIDs = append(role.ParentRoleIDs, pm.ParentRoleID)
+	}
+	return role
+}
+
+func FromDomain(r roles.Role) RoleModel {
+	model := RoleModel{
+		ID:        r.ID,
+		AccountID: r.AccountID,
+		Name:      r.Name,
+	}
+	for _, statement := range r.Statements {
+		model.Statements = append(model.Statements, RoleStatementModel{
+			RoleID:   r.ID,
+			Resource: statement.Resource,
+			Action:   string(statement.Action),
+			Effect:   string(statement.Effect),
+		})
+	}
+	for _, parentRoleID := range r.ParentRoleIDs {
+		model.ParentLinks = append(model.ParentLinks, RoleParentModel{
+			RoleID:       r.ID,
+			ParentRoleID: parentRoleID,
+		})
+	}
+	return model
+}
@@ -0,0 +1,168 @@
+package mysqlroles
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adhikag24/policy-based-permission-model/domain/roles"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, role *roles.Role) (*roles.Role, error) {
+	roleModel := FromDomain(*role)
+	if err := r.db.WithContext(ctx).Create(&roleModel).Error; err != nil {
+		return nil, err
+	}
+	response := ToDomain(roleModel)
+	return &response, nil
+}
+
+func (r *Repository) Update(ctx context.Context, role *roles.Role) (*roles.Role, error) {
+	roleModel := FromDomain(*role)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&RoleModel{}).Where("id = ? AND account_id = ?", role.ID, role.AccountID).
+			Update("name", role.Name)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return roles.ErrRoleNotFound
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&RoleStatementModel{}).Error; err != nil {
+			return err
+		}
+		if len(roleModel.Statements) > 0 {
+			if err := tx.Create(&roleModel.Statements).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&RoleParentModel{}).Error; err != nil {
+			return err
+		}
+		if len(roleModel.ParentLinks) > 0 {
+			return tx.Create(&roleModel.ParentLinks).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := ToDomain(roleModel)
+	return &response, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, request *roles.GetRoleRequest) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Child rows are only keyed by role, so make sure the role belongs to the account before touching them.
+		err := tx.Where("id = ? AND account_id = ?", request.RoleID, request.AccountID).First(&RoleModel{}).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return roles.ErrRoleNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", request.RoleID).Delete(&RoleAssignmentModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", request.RoleID).Delete(&RoleStatementModel{}).Error; err != nil {
+			return err
+		}
+		// Roles inheriting from the deleted role simply lose its statements.
+		if err := tx.Where("role_id = ? OR parent_role_id = ?", request.RoleID, request.RoleID).Delete(&RoleParentModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND account_id = ?", request.RoleID, request.AccountID).Delete(&RoleModel{}).Error
+	})
+}
+
+func (r *Repository) Get(ctx context.Context, request *roles.GetRoleRequest) (*roles.Role, error) {
+	var roleModel RoleModel
+	err := r.db.WithContext(ctx).Preload("Statements").Preload("ParentLinks").
+		Where("id = ? AND account_id = ?", request.RoleID, request.AccountID).First(&roleModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, roles.ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := ToDomain(roleModel)
+	return &response, nil
+}
+
+func (r *Repository) List(ctx context.Context, accountID int64) ([]roles.Role, error) {
+	var roleModels []RoleModel
+	err := r.db.WithContext(ctx).Preload("Statements").Preload("ParentLinks").Where("account_id = ?", accountID).Find(&roleModels).Error
+	if err != nil {
+		return nil, err
+	}
+	var response []roles.Role
+	for _, rm := range roleModels {
+		response = append(response, ToDomain(rm))
+	}
+	return response, nil
+}
+
+func (r *Repository) Assign(ctx context.Context, assignment *roles.Assignment) error {
+	return r.db.WithContext(ctx).Create(&RoleAssignmentModel{
+		AccountID:    assignment.AccountID,
+		RoleID:       assignment.RoleID,
+		TeamMemberID: assignment.TeamMemberID,
+	}).Error
+}
+
+func (r *Repository) Unassign(ctx context.Context, assignment *roles.Assignment) error {
+	return r.db.WithContext(ctx).Where("account_id = ? AND role_id = ? AND team_member_id = ?",
+		assignment.AccountID, assignment.RoleID, assignment.TeamMemberID).Delete(&RoleAssignmentModel{}).Error
+}
+
+// Retreives roles assigned to a team member, including their statements.
+func (r *Repository) GetByTeamMember(ctx context.Context, request *roles.GetByTeamMemberRequest) ([]roles.Role, error) {
+	var roleModels []RoleModel
+	err := r.db.WithContext(ctx).Preload("Statements").Preload("ParentLinks").
+		Joins("JOIN role_assignments ON role_assignments.role_id = roles.id").
+		Where("role_assignments.account_id = ? AND role_assignments.team_member_id = ?",
+			request.AccountID, request.TeamMemberID).
+		Find(&roleModels).Error
+	if err != nil {
+		return nil, err
+	}
+	var response []roles.Role
+	for _, rm := range roleModels {
+		response = append(response, ToDomain(rm))
+	}
+	return response, nil
+}
+
+func (r *Repository) GetAssignedTeamMemberIDs(ctx context.Context, accountID int64) ([]int64, error) {
+	var teamMemberIDs []int64
+	err := r.db.WithContext(ctx).Model(&RoleAssignmentModel{}).
+		Where("account_id = ?", accountID).
+		Distinct().Pluck("team_member_id", &teamMemberIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return teamMemberIDs, nil
+}
+
+func (r *Repository) IsAccountMember(ctx context.Context, request *roles.GetByTeamMemberRequest) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&AccountTeamMemberModel{}).
+		Where("account_id = ? AND team_member_id = ?", request.AccountID, request.TeamMemberID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,31 @@
+CREATE TABLE
+    account_groups (
+        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+        account_id BIGINT UNSIGNED NOT NULL,
+        name VARCHAR(100) NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        UNIQUE KEY uniq_group_name (account_id, name),
+        FOREIGN KEY (account_id) REFERENCES accounts (id)
+    );
+
+CREATE TABLE
+    account_group_members (
+        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+        account_id BIGINT UNSIGNED NOT NULL,
+        group_id BIGINT UNSIGNED NOT NULL,
+        team_member_id BIGINT UNSIGNED NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        UNIQUE KEY uniq_group_member (group_id, team_member_id),
+        FOREIGN KEY (group_id) REFERENCES account_groups (id) ON DELETE CASCADE,
+        FOREIGN KEY (account_id, team_member_id) REFERENCES account_team_members (account_id, team_member_id)
+    );
+
+-- Add index for faster lookups of groups a team member belongs to
+CREATE INDEX idx_account_group_members_account_id_team_member_id ON account_group_members (account_id, team_member_id);
+
+INSERT INTO
+    account_groups (account_id, name)
+VALUES
+    (1, 'Marketing'),
+    (1, 'Support');
@@ -2,9 +2,14 @@ CREATE TABLE
     policies (
         id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
         account_id BIGINT UNSIGNED NOT NULL,
-        team_member_id BIGINT UNSIGNED NOT NULL,
+        team_member_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+        group_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
         resource VARCHAR(255) NOT NULL,
         action VARCHAR(255) NOT NULL,
+        effect VARCHAR(16) NOT NULL DEFAULT 'allow',
+        condition_expr VARCHAR(1024) NOT NULL DEFAULT '',
+        not_before DATETIME NULL,
+        expires_at DATETIME NULL,
         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
     );
@@ -12,8 +17,33 @@ CREATE TABLE
 -- Add index for faster lookups on account_id, team_member_id, and action
 CREATE INDEX idx_policies_account_id_team_member_id_action ON policies (account_id, team_member_id, action);
 
+-- Add index for faster lookups on account_id, group_id, and action
+CREATE INDEX idx_policies_account_id_group_id_action ON policies (account_id, group_id, action);
+
+-- Add index for the expired policies sweeper
+CREATE INDEX idx_policies_expires_at ON policies (expires_at);
+
+-- Immutable history of every policy mutation, written in the same transaction as the mutation
+CREATE TABLE
+    policy_revisions (
+        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+        account_id BIGINT UNSIGNED NOT NULL,
+        team_member_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+        group_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+        policy_id BIGINT UNSIGNED NOT NULL,
+        operation VARCHAR(16) NOT NULL,
+        actor VARCHAR(255) NOT NULL DEFAULT '',
+        before_state JSON NULL,
+        after_state JSON NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+
+-- Add index for listing revisions per account and member
+CREATE INDEX idx_policy_revisions_account_id_team_member_id ON policy_revisions (account_id, team_member_id);
+CREATE INDEX idx_policy_revisions_account_id_created_at ON policy_revisions (account_id, created_at);
+
 INSERT INTO
     policies (account_id, team_member_id, resource, action)
 VALUES
-    (1, 2, '/blogs/*', 'read'),
-    (1, 2, '/funnels/page/12', 'write'),
\ No newline at end of file
+    (1, 2, 'blogs/*', 'read'),
+    (1, 2, 'funnels/12', 'write');
\ No newline at end of file
@@ -0,0 +1,45 @@
+CREATE TABLE
+    roles (
+        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+        account_id BIGINT UNSIGNED NOT NULL,
+        name VARCHAR(100) NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        UNIQUE KEY uniq_role_name (account_id, name),
+        FOREIGN KEY (account_id) REFERENCES accounts (id)
+    );
+
+CREATE TABLE
+    role_statements (
+        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+        role_id BIGINT UNSIGNED NOT NULL,
+        resource VARCHAR(255) NOT NULL,
+        action VARCHAR(255) NOT NULL,
+        effect VARCHAR(16) NOT NULL DEFAULT 'allow',
+        FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    role_parents (
+        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+        role_id BIGINT UNSIGNED NOT NULL,
+        parent_role_id BIGINT UNSIGNED NOT NULL,
+        UNIQUE KEY uniq_role_parent (role_id, parent_role_id),
+        FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE,
+        FOREIGN KEY (parent_role_id) REFERENCES roles (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    role_assignments (
+        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+        account_id BIGINT UNSIGNED NOT NULL,
+        role_id BIGINT UNSIGNED NOT NULL,
+        team_member_id BIGINT UNSIGNED NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        UNIQUE KEY uniq_role_assignment (account_id, role_id, team_member_id),
+        FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE,
+        FOREIGN KEY (team_member_id) REFERENCES team_members (id)
+    );
+
+-- Add index for faster lookups of roles assigned to a team member
+CREATE INDEX idx_role_assignments_account_id_team_member_id ON role_assignments (account_id, team_member_id);